@@ -1,3 +1,12 @@
 data "metaplane_connection" "snowflake" {
   name = "snowflake"
 }
+
+data "metaplane_connection" "snowflake_prod" {
+  name_regex = "^snowflake-prod$"
+  type       = "SNOWFLAKE"
+}
+
+data "metaplane_connection" "by_id" {
+  id = "00000000-0000-0000-0000-000000000000"
+}
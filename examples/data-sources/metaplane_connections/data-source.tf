@@ -0,0 +1,13 @@
+data "metaplane_connections" "snowflake" {
+  type       = "SNOWFLAKE"
+  is_enabled = true
+}
+
+data "metaplane_connections" "all" {}
+
+check "connections_healthy" {
+  assert {
+    condition     = alltrue([for c in data.metaplane_connections.all.connections : c.status != "FAILED"])
+    error_message = "Some Metaplane connections are in a failed status."
+  }
+}
@@ -3,6 +3,11 @@ data "metaplane_connection" "snowflake" {
 }
 
 data "metaplane_monitor" "monitor" {
+  monitor_id = "00000000-0000-0000-0000-000000000000"
+}
+
+data "metaplane_monitor" "orders_row_count" {
   connection_id = data.metaplane_connection.snowflake.id
-  monitor_id    = "string"
+  absolute_path = "ANALYTICS.FINANCE.ORDERS"
+  type          = "ROW_COUNT"
 }
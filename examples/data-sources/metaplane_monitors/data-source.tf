@@ -0,0 +1,18 @@
+data "metaplane_monitors" "finance_row_counts" {
+  connection_id = data.metaplane_connection.snowflake.id
+  path_prefix   = "ANALYTICS.FINANCE."
+  type          = "ROW_COUNT"
+  is_enabled    = true
+}
+
+# Adopt every existing freshness monitor of the connection.
+data "metaplane_monitors" "freshness" {
+  connection_id = data.metaplane_connection.snowflake.id
+  type          = "FRESHNESS"
+}
+
+import {
+  for_each = { for m in data.metaplane_monitors.freshness.monitors : m.absolute_path => m }
+  to       = metaplane_monitor.freshness[each.key]
+  id       = each.value.monitor_id
+}
@@ -0,0 +1,3 @@
+# Alert destinations can be imported by ID. Secrets are not returned by the API
+# and are set on the next apply.
+terraform import metaplane_alert_destination.slack 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,28 @@
+resource "metaplane_alert_destination" "slack" {
+  name = "Data alerts"
+
+  slack = {
+    channel = "#data-alerts"
+  }
+}
+
+resource "metaplane_alert_destination" "pagerduty" {
+  name = "Data on-call"
+
+  pagerduty = {
+    integration_key = var.pagerduty_integration_key
+    severity        = "error"
+  }
+}
+
+resource "metaplane_alert_destination" "webhook" {
+  name = "Incident bot"
+
+  webhook = {
+    url    = "https://example.com/hooks/metaplane"
+    secret = var.webhook_secret
+    headers = {
+      "X-Team" = "data"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Alert rules can be imported by ID.
+terraform import metaplane_alert_rule.finance 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,19 @@
+resource "metaplane_alert_rule" "finance" {
+  name = "Finance data"
+
+  connection_ids = [metaplane_connection.snowflake.id]
+  path_globs     = ["ANALYTICS.FINANCE.*"]
+  priorities     = ["CRITICAL", "HIGH"]
+
+  destination_ids = [
+    metaplane_alert_destination.slack.id,
+    metaplane_alert_destination.pagerduty.id,
+  ]
+
+  quiet_hours = {
+    start    = "22:00"
+    end      = "07:00"
+    timezone = "America/New_York"
+    days     = ["SAT", "SUN"]
+  }
+}
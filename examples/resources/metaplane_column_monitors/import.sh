@@ -0,0 +1,2 @@
+# Column monitor sets can be imported by connection ID and table path.
+terraform import metaplane_column_monitors.customers 00000000-0000-0000-0000-000000000000:ANALYTICS.MARTS.CUSTOMERS
@@ -0,0 +1,16 @@
+data "metaplane_connection" "snowflake" {
+  name = "snowflake"
+}
+
+resource "metaplane_column_monitors" "customers" {
+  connection_id = data.metaplane_connection.snowflake.id
+  absolute_path = "ANALYTICS.MARTS.CUSTOMERS"
+  cron_tab      = "0 6 * * *"
+
+  columns = {
+    CUSTOMER_ID  = ["NULLNESS", "UNIQUENESS"]
+    EMAIL        = ["NULLNESS"]
+    COUNTRY      = ["CARDINALITY"]
+    LIFETIME_GMV = ["MEAN", "MIN", "MAX"]
+  }
+}
@@ -0,0 +1,3 @@
+# Connections can be imported by ID. Credentials are not returned by the API
+# and are set on the next apply.
+terraform import metaplane_connection.snowflake 00000000-0000-0000-0000-000000000000
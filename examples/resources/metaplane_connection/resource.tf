@@ -0,0 +1,16 @@
+variable "snowflake_private_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "metaplane_connection" "snowflake" {
+  name = "snowflake-prod"
+
+  snowflake = {
+    account     = "xy12345.us-east-1"
+    username    = "METAPLANE"
+    private_key = var.snowflake_private_key
+    warehouse   = "METAPLANE_WH"
+    role        = "METAPLANE_ROLE"
+  }
+}
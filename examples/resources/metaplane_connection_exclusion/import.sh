@@ -0,0 +1,2 @@
+# Exclusion rules can be imported by connection ID.
+terraform import metaplane_connection_exclusion.snowflake 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,15 @@
+resource "metaplane_connection_exclusion" "snowflake" {
+  connection_id = metaplane_connection.snowflake.id
+
+  databases = {
+    include = ["ANALYTICS", "RAW"]
+  }
+
+  schemas = {
+    exclude = ["*.SCRATCH", "*.DBT_*"]
+  }
+
+  tables = {
+    exclude = ["*.*.TMP_*"]
+  }
+}
@@ -0,0 +1,2 @@
+# Sync settings can be imported by connection ID.
+terraform import metaplane_connection_sync_settings.snowflake 00000000-0000-0000-0000-000000000000
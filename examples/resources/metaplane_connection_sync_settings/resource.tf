@@ -0,0 +1,7 @@
+resource "metaplane_connection_sync_settings" "snowflake" {
+  connection_id         = metaplane_connection.snowflake.id
+  sync_frequency        = "6h"
+  query_history_enabled = false
+  warehouse             = "METAPLANE_XS"
+  role                  = "METAPLANE"
+}
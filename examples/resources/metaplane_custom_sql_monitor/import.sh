@@ -0,0 +1,2 @@
+# Custom SQL monitors can be imported by monitor ID.
+terraform import metaplane_custom_sql_monitor.refund_rate 00000000-0000-0000-0000-000000000000
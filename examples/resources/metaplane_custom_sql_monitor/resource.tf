@@ -0,0 +1,32 @@
+# Alert when invoices reference a customer that does not exist
+resource "metaplane_custom_sql_monitor" "orphan_invoices" {
+  connection_id = metaplane_connection.snowflake.id
+  absolute_path = "ANALYTICS.FINANCE.INVOICES"
+  cron_tab      = "0 * * * *"
+  result_mode   = "ZERO_ROWS"
+
+  sql = <<-SQL
+    SELECT i.id
+    FROM analytics.finance.invoices i
+    LEFT JOIN analytics.finance.customers c ON c.id = i.customer_id
+    WHERE c.id IS NULL
+  SQL
+}
+
+# Alert when the share of refunded orders leaves the expected range
+resource "metaplane_custom_sql_monitor" "refund_rate" {
+  connection_id = metaplane_connection.snowflake.id
+  absolute_path = "ANALYTICS.FINANCE.ORDERS"
+  cron_tab      = "0 6 * * *"
+  result_mode   = "THRESHOLD"
+  result_column = "REFUND_RATE"
+  lower_bound   = 0
+  upper_bound   = 0.05
+  priority      = "HIGH"
+
+  sql = <<-SQL
+    SELECT COUNT_IF(refunded) / COUNT(*) AS refund_rate, COUNT(*) AS orders
+    FROM analytics.finance.orders
+    WHERE ordered_at >= DATEADD(day, -1, CURRENT_DATE)
+  SQL
+}
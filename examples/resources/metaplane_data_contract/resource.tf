@@ -0,0 +1,29 @@
+resource "metaplane_data_contract" "invoices" {
+  connection_id = metaplane_connection.snowflake.id
+  absolute_path = "ANALYTICS.FINANCE.INVOICES"
+  cron_tab      = "0 * * * *"
+
+  columns = {
+    ID = {
+      type     = "NUMBER"
+      nullable = false
+    }
+    CUSTOMER_ID = {
+      type     = "NUMBER"
+      nullable = false
+    }
+    AMOUNT = {
+      type = "NUMBER(12,2)"
+    }
+    ISSUED_AT = {
+      type = "TIMESTAMP_NTZ"
+    }
+  }
+
+  freshness_sla = "6h"
+  min_row_count = 1000
+}
+
+output "invoices_contract_violations" {
+  value = metaplane_data_contract.invoices.violations
+}
@@ -0,0 +1,3 @@
+# dbt integrations can be imported by ID. The API token is not returned by the
+# API and is set on the next apply.
+terraform import metaplane_dbt_integration.production 00000000-0000-0000-0000-000000000000
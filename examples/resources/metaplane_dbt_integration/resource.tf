@@ -0,0 +1,11 @@
+resource "metaplane_dbt_integration" "production" {
+  connection_id = metaplane_connection.snowflake.id
+
+  account_id = 12345
+  project_id = 67890
+  job_ids    = [111, 222]
+  api_token  = var.dbt_cloud_token
+
+  ingest_tests          = true
+  ingest_model_metadata = true
+}
@@ -0,0 +1,2 @@
+# Maintenance windows can be imported by ID.
+terraform import metaplane_maintenance_window.weekly 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,22 @@
+# Skip the runs of the finance monitors during a one-off backfill
+resource "metaplane_maintenance_window" "backfill" {
+  name       = "Invoices backfill"
+  start_time = "2024-02-03T22:00:00Z"
+  end_time   = "2024-02-04T06:00:00Z"
+
+  connection_id = metaplane_connection.snowflake.id
+  path_globs    = ["ANALYTICS.FINANCE.*"]
+  action        = "SKIP_RUNS"
+}
+
+# Mute the alerts of some monitors during the weekly warehouse maintenance
+resource "metaplane_maintenance_window" "weekly" {
+  name     = "Weekly warehouse maintenance"
+  cron_tab = "0 2 * * SUN"
+  duration = "3h"
+  timezone = "America/New_York"
+
+  monitor_ids = [
+    metaplane_monitor.row_count.id,
+  ]
+}
@@ -15,4 +15,25 @@ resource "metaplane_monitor" "monitor" {
   incremental_days        = 1
   incremental_hours       = 0
   incremental_minutes     = 0
+
+  wait_for_first_run = true
+  first_run_timeout  = "15m"
+}
+
+resource "metaplane_monitor" "ledger_row_count" {
+  absolute_path = "FINANCE.PUBLIC.LEDGER"
+  entity_type   = "TABLE"
+  type          = "ROW_COUNT"
+  cron_tab      = "0 * * * *"
+  connection_id = data.metaplane_connection.snowflake.id
+
+  threshold_type = "STATIC"
+  lower_bound    = 1000
+  upper_bound    = 50000
+
+  name        = "Ledger row count"
+  description = "The ledger is loaded hourly and must keep between 1k and 50k rows"
+  owners      = ["finance-data@example.com"]
+  priority    = "CRITICAL"
+  tags        = ["finance"]
 }
@@ -0,0 +1,13 @@
+# Monitor the freshness of every dbt mart, including the ones added later
+resource "metaplane_monitor_group" "marts_freshness" {
+  connection_id = metaplane_connection.snowflake.id
+  name          = "marts-freshness"
+
+  include = ["ANALYTICS.MARTS.*"]
+  exclude = ["ANALYTICS.MARTS.TMP_*"]
+
+  template = {
+    type     = "FRESHNESS"
+    cron_tab = "0 * * * *"
+  }
+}
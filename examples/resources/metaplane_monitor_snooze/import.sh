@@ -0,0 +1,2 @@
+# Monitor snoozes can be imported by monitor ID.
+terraform import metaplane_monitor_snooze.orders_backfill 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,5 @@
+resource "metaplane_monitor_snooze" "orders_backfill" {
+  monitor_id = metaplane_monitor.orders_row_count.id
+  until      = "2026-11-02T09:00:00Z"
+  reason     = "Backfilling orders, row counts are expected to spike"
+}
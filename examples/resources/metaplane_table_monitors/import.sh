@@ -0,0 +1,2 @@
+# Table monitor sets can be imported by connection ID and table path.
+terraform import metaplane_table_monitors.orders 00000000-0000-0000-0000-000000000000:ANALYTICS.MARTS.ORDERS
@@ -0,0 +1,13 @@
+data "metaplane_connection" "snowflake" {
+  name = "snowflake"
+}
+
+resource "metaplane_table_monitors" "orders" {
+  connection_id = data.metaplane_connection.snowflake.id
+  absolute_path = "ANALYTICS.MARTS.ORDERS"
+  kinds         = ["ROW_COUNT", "FRESHNESS", "SCHEMA_CHANGE"]
+  cron_tab      = "0 * * * *"
+
+  incremental_column_name = "UPDATED_AT"
+  incremental_days        = 7
+}
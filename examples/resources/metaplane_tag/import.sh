@@ -0,0 +1,2 @@
+# Tags can be imported by ID.
+terraform import metaplane_tag.finance 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,5 @@
+resource "metaplane_tag" "finance" {
+  name        = "finance"
+  color       = "#1f77b4"
+  description = "Tables and monitors owned by the finance data team"
+}
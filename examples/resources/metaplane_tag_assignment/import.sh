@@ -0,0 +1,2 @@
+# Tag assignments can be imported by tag ID.
+terraform import metaplane_tag_assignment.finance 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,14 @@
+resource "metaplane_tag_assignment" "finance" {
+  tag_id = metaplane_tag.finance.id
+
+  monitor_ids = [
+    metaplane_monitor.row_count.id,
+  ]
+
+  tables = [
+    {
+      connection_id = metaplane_connection.snowflake.id
+      absolute_path = "ANALYTICS.FINANCE.INVOICES"
+    },
+  ]
+}
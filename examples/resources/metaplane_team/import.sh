@@ -0,0 +1,2 @@
+# Teams can be imported by ID.
+terraform import metaplane_team.finance 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,9 @@
+resource "metaplane_team" "finance" {
+  name = "Finance data"
+
+  member_ids = [
+    metaplane_user.jane.id,
+  ]
+
+  default_alert_destination_id = metaplane_alert_destination.slack.id
+}
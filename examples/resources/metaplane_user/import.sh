@@ -0,0 +1,3 @@
+# Users can be imported by ID or by email address.
+terraform import metaplane_user.jane 00000000-0000-0000-0000-000000000000
+terraform import metaplane_user.jane jane@example.com
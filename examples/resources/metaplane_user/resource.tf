@@ -0,0 +1,4 @@
+resource "metaplane_user" "jane" {
+  email = "jane@example.com"
+  role  = "EDITOR"
+}
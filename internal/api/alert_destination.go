@@ -0,0 +1,130 @@
+/*
+Implement CRUD for AlertDestination
+
+GetAlertDestination: built-in
+CreateAlertDestination: built-in
+UpdateAlertDestination: built-in
+DeleteAlertDestination: built-in
+  Secrets, such as integration keys and webhook secrets, are write-only and
+  are never returned by the API.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Alert destination types, one per supported destination kind.
+const (
+	AlertDestinationTypeSlack     = "SLACK"
+	AlertDestinationTypeEmail     = "EMAIL"
+	AlertDestinationTypePagerDuty = "PAGERDUTY"
+	AlertDestinationTypeTeams     = "TEAMS"
+	AlertDestinationTypeWebhook   = "WEBHOOK"
+)
+
+type SlackDestination struct {
+	Channel string `json:"channel"`
+}
+
+type EmailDestination struct {
+	Addresses []string `json:"addresses"`
+}
+
+type PagerDutyDestination struct {
+	IntegrationKey string  `json:"integrationKey,omitempty"`
+	Severity       *string `json:"severity,omitempty"`
+}
+
+type TeamsDestination struct {
+	WebhookUrl string `json:"webhookUrl,omitempty"`
+}
+
+type WebhookDestination struct {
+	Url     string            `json:"url"`
+	Secret  *string           `json:"secret,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// AlertDestination is both the request body and the response of the alert
+// destination endpoints. Exactly one of the destination kinds is set.
+type AlertDestination struct {
+	ID        string                `json:"id,omitempty"`
+	Name      string                `json:"name"`
+	Type      string                `json:"type"`
+	Slack     *SlackDestination     `json:"slack,omitempty"`
+	Email     *EmailDestination     `json:"email,omitempty"`
+	PagerDuty *PagerDutyDestination `json:"pagerduty,omitempty"`
+	Teams     *TeamsDestination     `json:"teams,omitempty"`
+	Webhook   *WebhookDestination   `json:"webhook,omitempty"`
+	CreatedAt string                `json:"createdAt,omitempty"`
+	UpdatedAt string                `json:"updatedAt,omitempty"`
+}
+
+func (c *Client) GetAlertDestination(destinationId string) (*AlertDestination, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/alert-destinations/%s", BaseUrl, destinationId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	destination := AlertDestination{}
+	err = json.Unmarshal(body, &destination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &destination, nil
+}
+
+func (c *Client) CreateAlertDestination(destination AlertDestination) (*AlertDestination, error) {
+	return c.writeAlertDestination(fmt.Sprintf("%s/alert-destinations", BaseUrl), destination)
+}
+
+func (c *Client) UpdateAlertDestination(destination AlertDestination) (*AlertDestination, error) {
+	return c.writeAlertDestination(fmt.Sprintf("%s/alert-destinations/%s", BaseUrl, destination.ID), destination)
+}
+
+func (c *Client) DeleteAlertDestination(destinationId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/alert-destinations/%s", BaseUrl, destinationId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeAlertDestination(url string, destination AlertDestination) (*AlertDestination, error) {
+	rb, err := json.Marshal(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := AlertDestination{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,116 @@
+/*
+Implement CRUD for AlertRule
+
+GetAlertRule: built-in
+CreateAlertRule: built-in
+UpdateAlertRule: built-in
+DeleteAlertRule: built-in
+  An alert rule routes the incidents of the monitors it matches to one or
+  more alert destinations. Empty match lists match every monitor.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AlertRuleMatch selects the monitors of an alert rule. A monitor matches
+// when it matches every non-empty list.
+type AlertRuleMatch struct {
+	ConnectionIds []string `json:"connectionIds"`
+	PathGlobs     []string `json:"pathGlobs"`
+	MonitorTypes  []string `json:"monitorTypes"`
+	Tags          []string `json:"tags"`
+	Priorities    []string `json:"priorities"`
+}
+
+// QuietHours is the daily window during which an alert rule sends nothing.
+// Start and End are "HH:MM" in Timezone, the window wraps past midnight when
+// End is before Start.
+type QuietHours struct {
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone"`
+	Days     []string `json:"days,omitempty"`
+}
+
+// AlertRule is both the request body and the response of the alert rule
+// endpoints.
+type AlertRule struct {
+	ID             string         `json:"id,omitempty"`
+	Name           string         `json:"name"`
+	IsEnabled      bool           `json:"isEnabled"`
+	Match          AlertRuleMatch `json:"match"`
+	DestinationIds []string       `json:"destinationIds"`
+	QuietHours     *QuietHours    `json:"quietHours"`
+	CreatedAt      string         `json:"createdAt,omitempty"`
+	UpdatedAt      string         `json:"updatedAt,omitempty"`
+}
+
+func (c *Client) GetAlertRule(ruleId string) (*AlertRule, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/alert-rules/%s", BaseUrl, ruleId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	rule := AlertRule{}
+	err = json.Unmarshal(body, &rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (c *Client) CreateAlertRule(rule AlertRule) (*AlertRule, error) {
+	return c.writeAlertRule(fmt.Sprintf("%s/alert-rules", BaseUrl), rule)
+}
+
+func (c *Client) UpdateAlertRule(rule AlertRule) (*AlertRule, error) {
+	return c.writeAlertRule(fmt.Sprintf("%s/alert-rules/%s", BaseUrl, rule.ID), rule)
+}
+
+func (c *Client) DeleteAlertRule(ruleId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/alert-rules/%s", BaseUrl, ruleId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeAlertRule(url string, rule AlertRule) (*AlertRule, error) {
+	rb, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := AlertRule{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,84 @@
+/*
+Implement the table catalog of a connection
+
+ListTables: requires connection_id
+  Lists the tables and views Metaplane discovered on a connection, after the
+  exclusion rules of the connection are applied.
+ListColumns: requires connection_id and absolute_path
+  Lists the columns of a table as last synced from the warehouse.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Table is an entry of the table catalog of a connection.
+type Table struct {
+	AbsolutePath string `json:"absolutePath"`
+	// Type is TABLE or VIEW.
+	Type string `json:"type"`
+}
+
+type Tables struct {
+	Data []Table `json:"data"`
+}
+
+// Column is a column of a table of the catalog.
+type Column struct {
+	Name string `json:"name"`
+	// Type is the warehouse type, e.g. NUMBER(38,0) or VARCHAR.
+	Type       string `json:"type"`
+	IsNullable bool   `json:"isNullable"`
+}
+
+type Columns struct {
+	Data []Column `json:"data"`
+}
+
+func (c *Client) ListTables(connectionId string) ([]Table, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/connections/%s/tables", BaseUrl, connectionId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	tables := Tables{}
+	err = json.Unmarshal(body, &tables)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables.Data, nil
+}
+
+func (c *Client) ListColumns(connectionId string, absolutePath string) ([]Column, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/connections/%s/tables/columns?absolutePath=%s", BaseUrl, connectionId, url.QueryEscape(absolutePath)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	columns := Columns{}
+	err = json.Unmarshal(body, &columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return columns.Data, nil
+}
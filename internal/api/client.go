@@ -8,6 +8,7 @@ url := "https://dev.api.metaplane.dev/v1/monitors/status/monitorId"
 package api
 
 import (
+  "fmt"
   "io"
   "net/http"
   "encoding/json"
@@ -23,6 +24,25 @@ type Client struct {
   HTTPClient        *retryablehttp.Client
 }
 
+// ErrNotFound is returned, wrapped, when the requested object does not exist.
+var ErrNotFound = errors.New("not found")
+
+// StatusError is returned for responses with an error status code other
+// than 404, which is reported as ErrNotFound.
+type StatusError struct {
+  StatusCode      int
+  Message         string
+}
+
+func (e *StatusError) Error() string {
+  return e.Message
+}
+
+// Temporary reports whether the request may succeed when retried later.
+func (e *StatusError) Temporary() bool {
+  return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
 type ErrorResponse struct {
   StatusCode      int         `json:"statusCode"`
   ErrorMessage    string      `json:"errorMessage"`
@@ -51,16 +71,21 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
   }
 
 	statusCode := res.StatusCode
-  if statusCode == 200 {
+  if statusCode >= 200 && statusCode < 300 {
     return body, err
   }
 
+  // Error bodies are usually JSON, but gateways and proxies may answer with
+  // an empty or HTML body, so the message is optional.
+  message := http.StatusText(statusCode)
   error_response := ErrorResponse{}
-  err = json.Unmarshal(body, &error_response)
-  if err != nil {
-    return nil, err
+  if json.Unmarshal(body, &error_response) == nil && error_response.ErrorMessage != "" {
+    message = error_response.ErrorMessage
+  }
+  if statusCode == http.StatusNotFound {
+    return nil, fmt.Errorf("%w: %s", ErrNotFound, message)
   }
-  return nil, errors.New(error_response.ErrorMessage)
+  return nil, &StatusError{StatusCode: statusCode, Message: message}
 }
 
 func NewClient(apiKey *string) *Client {
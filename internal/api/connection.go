@@ -1,10 +1,15 @@
 /*
 Implement CRUD for Connection
 
+ListConnections: built-in
 GetConnection: requires connection_id
   Metaplane does not have a GET method specifically for connections. Instead,
-  use the GET method for the API (list all). In the response, use the 
-  name to get the desired connection.
+  use ListConnections. In the response, use the name to get the desired
+  connection. Fails when several connections have the name.
+GetConnectionById: same as GetConnection, matching on the id instead.
+CreateConnection: built-in
+UpdateConnection: built-in
+DeleteConnection: built-in
 */
 package api
 
@@ -13,6 +18,16 @@ import (
   "encoding/json"
   "net/http"
   "errors"
+  "strings"
+)
+
+// Connection types, one per supported warehouse.
+const (
+  ConnectionTypeSnowflake  = "SNOWFLAKE"
+  ConnectionTypeBigQuery   = "BIGQUERY"
+  ConnectionTypeRedshift   = "REDSHIFT"
+  ConnectionTypePostgres   = "POSTGRES"
+  ConnectionTypeDatabricks = "DATABRICKS"
 )
 
 type Connection struct {
@@ -25,7 +40,62 @@ type Connection struct {
   Status          string     `json:"status"`
 }
 
-func (c *Client) GetConnection(name string) (*Connection, error) {
+type SnowflakeCredentials struct {
+  Account         string     `json:"account"`
+  Username        string     `json:"username"`
+  Password        *string    `json:"password,omitempty"`
+  PrivateKey      *string    `json:"privateKey,omitempty"`
+  Warehouse       *string    `json:"warehouse,omitempty"`
+  Role            *string    `json:"role,omitempty"`
+  Database        *string    `json:"database,omitempty"`
+}
+
+type BigQueryCredentials struct {
+  ProjectId         string   `json:"projectId"`
+  ServiceAccountKey string   `json:"serviceAccountKey"`
+  Location          *string  `json:"location,omitempty"`
+}
+
+// DatabaseCredentials are used by Redshift and Postgres connections.
+type DatabaseCredentials struct {
+  Host            string     `json:"host"`
+  Port            int64      `json:"port"`
+  Database        string     `json:"database"`
+  Username        string     `json:"username"`
+  Password        string     `json:"password"`
+}
+
+type DatabricksCredentials struct {
+  Host            string     `json:"host"`
+  HttpPath        string     `json:"httpPath"`
+  Token           string     `json:"token"`
+  Catalog         *string    `json:"catalog,omitempty"`
+}
+
+// ConnectionCredentials holds the credentials of exactly one warehouse.
+type ConnectionCredentials struct {
+  Snowflake       *SnowflakeCredentials   `json:"snowflake,omitempty"`
+  BigQuery        *BigQueryCredentials    `json:"bigquery,omitempty"`
+  Redshift        *DatabaseCredentials    `json:"redshift,omitempty"`
+  Postgres        *DatabaseCredentials    `json:"postgres,omitempty"`
+  Databricks      *DatabricksCredentials  `json:"databricks,omitempty"`
+}
+
+type NewConnection struct {
+  Name            string                 `json:"name"`
+  Type            string                 `json:"type"`
+  IsEnabled       bool                   `json:"isEnabled"`
+  Credentials     ConnectionCredentials  `json:"credentials"`
+}
+
+type UpdateConnection struct {
+  ConnectionId    string                 `json:"-"`
+  Name            string                 `json:"name,omitempty"`
+  IsEnabled       *bool                  `json:"isEnabled,omitempty"`
+  Credentials     *ConnectionCredentials `json:"credentials,omitempty"`
+}
+
+func (c *Client) ListConnections() ([]Connection, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/connections", BaseUrl), nil)
 	if err != nil {
 		return nil, err
@@ -44,12 +114,102 @@ func (c *Client) GetConnection(name string) (*Connection, error) {
 		return nil, err
 	}
 
-	for _, connection := range connections {
-		if connection.Name == name {
-      return &connection, nil
+  return connections, nil
+}
+
+func (c *Client) GetConnection(name string) (*Connection, error) {
+  connections, err := c.ListConnections()
+	if err != nil {
+		return nil, err
+	}
+
+  var match *Connection
+	for i := range connections {
+		if connections[i].Name == name {
+      if match != nil {
+        return nil, fmt.Errorf("connection name %q is ambiguous, it is used by connections %s and %s", name, match.ConnectionId, connections[i].ConnectionId)
+      }
+      match = &connections[i]
 		}
 	}
 
-  return nil, errors.New("Connection is not found")
+  if match == nil {
+    return nil, errors.New("Connection is not found")
+  }
+  return match, nil
 }
 
+func (c *Client) GetConnectionById(connectionId string) (*Connection, error) {
+  connections, err := c.ListConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range connections {
+		if connections[i].ConnectionId == connectionId {
+      return &connections[i], nil
+		}
+	}
+
+  return nil, fmt.Errorf("%w: connection %s", ErrNotFound, connectionId)
+}
+
+func (c *Client) CreateConnection(newConnection NewConnection) (*Connection, error) {
+  rb, err := json.Marshal(newConnection)
+  if err != nil {
+    return nil, err
+  }
+
+  req, err := http.NewRequest("POST", fmt.Sprintf("%s/connections", BaseUrl), strings.NewReader(string(rb)))
+  if err != nil {
+    return nil, err
+  }
+
+  body, err := c.doRequest(req)
+  if err != nil {
+    return nil, err
+  }
+
+  connection := Connection{}
+  err = json.Unmarshal(body, &connection)
+  if err != nil {
+    return nil, err
+  }
+
+  return &connection, nil
+}
+
+func (c *Client) UpdateConnection(updateConnection UpdateConnection) (*Connection, error) {
+  rb, err := json.Marshal(updateConnection)
+  if err != nil {
+    return nil, err
+  }
+
+  req, err := http.NewRequest("POST", fmt.Sprintf("%s/connections/%s", BaseUrl, updateConnection.ConnectionId), strings.NewReader(string(rb)))
+  if err != nil {
+    return nil, err
+  }
+
+  body, err := c.doRequest(req)
+  if err != nil {
+    return nil, err
+  }
+
+  connection := Connection{}
+  err = json.Unmarshal(body, &connection)
+  if err != nil {
+    return nil, err
+  }
+
+  return &connection, nil
+}
+
+func (c *Client) DeleteConnection(connectionId string) error {
+  req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/connections/%s", BaseUrl, connectionId), nil)
+  if err != nil {
+    return err
+  }
+
+  _, err = c.doRequest(req)
+  return err
+}
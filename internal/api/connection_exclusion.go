@@ -0,0 +1,87 @@
+/*
+Implement CRUD for ConnectionExclusions
+
+GetConnectionExclusions: built-in
+SetConnectionExclusions: built-in
+  Replaces the exclusion rules of a connection. There is one set of rules
+  per connection, setting empty rules makes Metaplane monitor everything the
+  connection can see again.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Pattern syntaxes of the exclusion rules.
+const (
+	PatternSyntaxGlob  = "GLOB"
+	PatternSyntaxRegex = "REGEX"
+)
+
+// PatternRules selects objects by name. An object is excluded when it
+// matches an Exclude pattern, or when Include is not empty and it matches
+// none of its patterns.
+type PatternRules struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// ConnectionExclusions are the rules limiting what Metaplane discovers and
+// monitors on a connection.
+type ConnectionExclusions struct {
+	PatternSyntax string       `json:"patternSyntax"`
+	Databases     PatternRules `json:"databases"`
+	Schemas       PatternRules `json:"schemas"`
+	Tables        PatternRules `json:"tables"`
+}
+
+func (c *Client) GetConnectionExclusions(connectionId string) (*ConnectionExclusions, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/connections/%s/exclusions", BaseUrl, connectionId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	exclusions := ConnectionExclusions{}
+	err = json.Unmarshal(body, &exclusions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exclusions, nil
+}
+
+func (c *Client) SetConnectionExclusions(connectionId string, exclusions ConnectionExclusions) (*ConnectionExclusions, error) {
+	rb, err := json.Marshal(exclusions)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/connections/%s/exclusions", BaseUrl, connectionId), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ConnectionExclusions{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
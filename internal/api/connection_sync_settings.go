@@ -0,0 +1,85 @@
+/*
+Implement CRUD for ConnectionSyncSettings
+
+GetConnectionSyncSettings: built-in
+SetConnectionSyncSettings: built-in
+  Settings left unset in the request keep their current value.
+ResetConnectionSyncSettings: built-in
+  Restores the Metaplane defaults of a connection.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ConnectionSyncSettings control how often Metaplane syncs the metadata of
+// a connection and which compute it uses to run monitor queries. There is
+// one set of settings per connection.
+type ConnectionSyncSettings struct {
+	SyncIntervalMinutes *int64  `json:"syncIntervalMinutes,omitempty"`
+	QueryHistoryEnabled *bool   `json:"queryHistoryEnabled,omitempty"`
+	Warehouse           *string `json:"warehouse,omitempty"`
+	Role                *string `json:"role,omitempty"`
+	LastSyncedAt        *string `json:"lastSyncedAt,omitempty"`
+}
+
+func (c *Client) GetConnectionSyncSettings(connectionId string) (*ConnectionSyncSettings, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/connections/%s/sync-settings", BaseUrl, connectionId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	settings := ConnectionSyncSettings{}
+	err = json.Unmarshal(body, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (c *Client) SetConnectionSyncSettings(connectionId string, settings ConnectionSyncSettings) (*ConnectionSyncSettings, error) {
+	rb, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/connections/%s/sync-settings", BaseUrl, connectionId), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ConnectionSyncSettings{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) ResetConnectionSyncSettings(connectionId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/connections/%s/sync-settings", BaseUrl, connectionId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
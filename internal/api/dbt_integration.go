@@ -0,0 +1,99 @@
+/*
+Implement CRUD for DbtIntegration
+
+GetDbtIntegration: built-in
+CreateDbtIntegration: built-in
+UpdateDbtIntegration: built-in
+DeleteDbtIntegration: built-in
+  The dbt Cloud API token is write-only and is never returned by the API.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DbtIntegration connects dbt Cloud jobs to a connection, so that their
+// lineage, model metadata and test results are ingested. It is both the
+// request body and the response of the dbt integration endpoints.
+type DbtIntegration struct {
+	ID                  string  `json:"id,omitempty"`
+	ConnectionId        string  `json:"connectionId"`
+	AccountId           int64   `json:"accountId"`
+	ProjectId           int64   `json:"projectId"`
+	JobIds              []int64 `json:"jobIds"`
+	ApiToken            string  `json:"apiToken,omitempty"`
+	IngestTests         bool    `json:"ingestTests"`
+	IngestModelMetadata bool    `json:"ingestModelMetadata"`
+	Status              string  `json:"status,omitempty"`
+	CreatedAt           string  `json:"createdAt,omitempty"`
+	UpdatedAt           string  `json:"updatedAt,omitempty"`
+}
+
+func (c *Client) GetDbtIntegration(integrationId string) (*DbtIntegration, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/integrations/dbt/%s", BaseUrl, integrationId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	integration := DbtIntegration{}
+	err = json.Unmarshal(body, &integration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &integration, nil
+}
+
+func (c *Client) CreateDbtIntegration(integration DbtIntegration) (*DbtIntegration, error) {
+	return c.writeDbtIntegration(fmt.Sprintf("%s/integrations/dbt", BaseUrl), integration)
+}
+
+func (c *Client) UpdateDbtIntegration(integration DbtIntegration) (*DbtIntegration, error) {
+	return c.writeDbtIntegration(fmt.Sprintf("%s/integrations/dbt/%s", BaseUrl, integration.ID), integration)
+}
+
+func (c *Client) DeleteDbtIntegration(integrationId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/integrations/dbt/%s", BaseUrl, integrationId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeDbtIntegration(url string, integration DbtIntegration) (*DbtIntegration, error) {
+	rb, err := json.Marshal(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := DbtIntegration{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
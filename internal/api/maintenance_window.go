@@ -0,0 +1,116 @@
+/*
+Implement CRUD for MaintenanceWindow
+
+GetMaintenanceWindow: built-in
+CreateMaintenanceWindow: built-in
+UpdateMaintenanceWindow: built-in
+DeleteMaintenanceWindow: built-in
+  Deleting a maintenance window ends it immediately, including an occurrence
+  that is in progress.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Maintenance window actions, applied to the matched monitors while the
+// window is active.
+const (
+	MaintenanceActionMuteAlerts = "MUTE_ALERTS"
+	MaintenanceActionSkipRuns   = "SKIP_RUNS"
+)
+
+// MaintenanceWindowScope selects the monitors of a maintenance window. A
+// monitor matches when it matches every set field.
+type MaintenanceWindowScope struct {
+	ConnectionId *string  `json:"connectionId"`
+	PathGlobs    []string `json:"pathGlobs"`
+	MonitorIds   []string `json:"monitorIds"`
+}
+
+// MaintenanceWindow is both the request body and the response of the
+// maintenance window endpoints. A window is either one-off, from StartsAt to
+// EndsAt, or recurring, starting on CronTab for DurationMinutes.
+type MaintenanceWindow struct {
+	ID              string                 `json:"id,omitempty"`
+	Name            string                 `json:"name"`
+	StartsAt        *string                `json:"startsAt"`
+	EndsAt          *string                `json:"endsAt"`
+	CronTab         *string                `json:"cronTab"`
+	DurationMinutes *int64                 `json:"durationMinutes"`
+	Timezone        string                 `json:"timezone"`
+	Scope           MaintenanceWindowScope `json:"scope"`
+	Action          string                 `json:"action"`
+	IsActive        bool                   `json:"isActive,omitempty"`
+	CreatedAt       string                 `json:"createdAt,omitempty"`
+	UpdatedAt       string                 `json:"updatedAt,omitempty"`
+}
+
+func (c *Client) GetMaintenanceWindow(windowId string) (*MaintenanceWindow, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/maintenance-windows/%s", BaseUrl, windowId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	window := MaintenanceWindow{}
+	err = json.Unmarshal(body, &window)
+	if err != nil {
+		return nil, err
+	}
+
+	return &window, nil
+}
+
+func (c *Client) CreateMaintenanceWindow(window MaintenanceWindow) (*MaintenanceWindow, error) {
+	return c.writeMaintenanceWindow(fmt.Sprintf("%s/maintenance-windows", BaseUrl), window)
+}
+
+func (c *Client) UpdateMaintenanceWindow(window MaintenanceWindow) (*MaintenanceWindow, error) {
+	return c.writeMaintenanceWindow(fmt.Sprintf("%s/maintenance-windows/%s", BaseUrl, window.ID), window)
+}
+
+func (c *Client) DeleteMaintenanceWindow(windowId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/maintenance-windows/%s", BaseUrl, windowId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeMaintenanceWindow(url string, window MaintenanceWindow) (*MaintenanceWindow, error) {
+	rb, err := json.Marshal(window)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := MaintenanceWindow{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
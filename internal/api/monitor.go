@@ -6,6 +6,9 @@ GetMonitor: requires monitor_id and connection_id.
   the GET method for the API (list for connection), which requires the
   connection_id. In the response, use the monitor_id to get the monitor.
 CreateMonitor: built-in
+  When a monitor with the same path and type already exists, it is adopted:
+  enabled and updated with the new configuration. Callers that must not take
+  over monitors pass adoptExisting false and get ErrMonitorExists instead.
 UpdateMonitor: built-in
 DeleteMonitor: there is no delete method for monitors. Instead, use the UPDATE method
   to set the monitor to inactive.
@@ -19,8 +22,20 @@ import (
   "net/http"
   "errors"
   "strings"
+  "time"
 )
 
+// Reads following a write are retried readAfterWriteAttempts times, starting
+// with a readAfterWriteBackoff delay that doubles after every attempt.
+const (
+  readAfterWriteAttempts = 5
+  readAfterWriteBackoff  = 500 * time.Millisecond
+)
+
+// ErrMonitorExists is returned, wrapped, by CreateMonitor when a monitor with
+// the same path and type exists and may not be adopted.
+var ErrMonitorExists = errors.New("monitor already exists")
+
 type Duration struct {
   Days              *int64              `json:"days,omitempty"`
   Hours             *int64              `json:"hours,omitempty"`
@@ -32,10 +47,39 @@ type IncrementalClause struct {
   Duration          *Duration          `json:"duration,omitempty"`
 }
 
+// Threshold types. STATIC alerts outside of the manual bounds, ML uses the
+// anomaly model trained by Metaplane.
+const (
+  ThresholdTypeStatic = "STATIC"
+  ThresholdTypeML     = "ML"
+)
+
+// Result modes of CUSTOM_SQL monitors. ZERO_ROWS alerts when the query
+// returns rows, THRESHOLD when the result is outside of the manual bounds and
+// ANOMALY when the anomaly model flags the result.
+const (
+  CustomSqlResultZeroRows  = "ZERO_ROWS"
+  CustomSqlResultThreshold = "THRESHOLD"
+  CustomSqlResultAnomaly   = "ANOMALY"
+)
+
+type Threshold struct {
+  Type              string             `json:"type"`
+  LowerBound        *float64           `json:"lowerBound,omitempty"`
+  UpperBound        *float64           `json:"upperBound,omitempty"`
+}
+
 type Config struct {
   CustomSql         *string            `json:"customSql,omitempty"`
+  // ResultMode and ResultColumn only apply to CUSTOM_SQL monitors.
+  ResultMode        *string            `json:"resultMode,omitempty"`
+  ResultColumn      *string            `json:"resultColumn,omitempty"`
   IncrementalClause *IncrementalClause `json:"incrementalClause,omitempty"`
   CustomWhereClause *string            `json:"customWhereClause,omitempty"`
+  Threshold         *Threshold         `json:"threshold,omitempty"`
+  Sensitivity       *string            `json:"sensitivity,omitempty"`
+  TrainingWindow    *Duration          `json:"trainingWindow,omitempty"`
+  LookbackWindow    *Duration          `json:"lookbackWindow,omitempty"`
 }
 
 type Monitor struct {
@@ -49,6 +93,11 @@ type Monitor struct {
   AbsolutePath      string             `json:"absolutePath"`
   ConnectionId      string             `json:"connectionId"`
   EntityType        string             `json:"entityType"`
+  Name              string             `json:"name"`
+  Description       string             `json:"description"`
+  Owners            []string           `json:"owners"`
+  Priority          string             `json:"priority"`
+  Tags              []string           `json:"tags"`
 }
 
 type NewMonitor struct {
@@ -58,6 +107,11 @@ type NewMonitor struct {
   CronTab             string           `json:"cronTab"`
   AbsolutePath        string           `json:"absolutePathString"`
   Config              Config           `json:"config,omitempty"`
+  Name                *string          `json:"name,omitempty"`
+  Description         *string          `json:"description,omitempty"`
+  Owners              []string         `json:"owners,omitempty"`
+  Priority            *string          `json:"priority,omitempty"`
+  Tags                []string         `json:"tags,omitempty"`
 }
 
 type Monitors struct {
@@ -67,8 +121,16 @@ type Monitors struct {
 type UpdateMonitor struct {
   MonitorId           string
   CronTab             string           `json:"cronTab,omitempty"`
-  IsEnabled           bool             `json:"isEnabled,omitempty"`
+  // IsEnabled is always sent, false disables the monitor.
+  IsEnabled           bool             `json:"isEnabled"`
   Config              Config           `json:"config,omitempty"`
+  // Metadata fields are pointers so that an empty value clears the field
+  // while a nil value leaves it untouched.
+  Name                *string          `json:"name,omitempty"`
+  Description         *string          `json:"description,omitempty"`
+  Owners              *[]string        `json:"owners,omitempty"`
+  Priority            *string          `json:"priority,omitempty"`
+  Tags                *[]string        `json:"tags,omitempty"`
 }
 
 func (c *Client) GetMonitor(monitorId string) (*Monitor, error) {
@@ -93,7 +155,34 @@ func (c *Client) GetMonitor(monitorId string) (*Monitor, error) {
   return &monitor, nil
 }
 
-func (c *Client) CreateMonitor(ctx context.Context, newMonitor NewMonitor) (*Monitor, error) {
+// GetMonitorAfterWrite reads back a monitor that was just created or updated.
+// The API is eventually consistent, so the read is retried with a backoff
+// until the monitor is found and is at least as recent as the written one.
+func (c *Client) GetMonitorAfterWrite(ctx context.Context, written *Monitor) (*Monitor, error) {
+  backoff := readAfterWriteBackoff
+  for attempt := 1; ; attempt++ {
+    monitor, err := c.GetMonitor(written.ID)
+    if err == nil && !isOlder(monitor.UpdatedAt, written.UpdatedAt) {
+      return monitor, nil
+    }
+
+    if attempt == readAfterWriteAttempts {
+      if err != nil {
+        return nil, err
+      }
+      return nil, fmt.Errorf("monitor %s is still not updated after %d reads", written.ID, attempt)
+    }
+
+    select {
+    case <-ctx.Done():
+      return nil, ctx.Err()
+    case <-time.After(backoff):
+    }
+    backoff *= 2
+  }
+}
+
+func (c *Client) CreateMonitor(ctx context.Context, newMonitor NewMonitor, adoptExisting bool) (*Monitor, error) {
   rb, err := json.Marshal(newMonitor)
   if err != nil {
  	  return nil, err
@@ -111,12 +200,27 @@ func (c *Client) CreateMonitor(ctx context.Context, newMonitor NewMonitor) (*Mon
       if err != nil {
         return nil, err
       }
+      if !adoptExisting {
+        return nil, fmt.Errorf("%w: %s monitor on %s has ID %s", ErrMonitorExists, newMonitor.Type, newMonitor.AbsolutePath, monitorId)
+      }
 
       // update isEnabled to true
       updateMonitor := UpdateMonitor {
-        MonitorId: monitorId,
-        IsEnabled: true,
-        CronTab:   newMonitor.CronTab,
+        MonitorId:   monitorId,
+        IsEnabled:   true,
+        CronTab:     newMonitor.CronTab,
+        Config:      newMonitor.Config,
+        Name:        newMonitor.Name,
+        Description: newMonitor.Description,
+        Priority:    newMonitor.Priority,
+      }
+      // nil owners and tags were not configured, keep the ones of the
+      // adopted monitor
+      if newMonitor.Owners != nil {
+        updateMonitor.Owners = &newMonitor.Owners
+      }
+      if newMonitor.Tags != nil {
+        updateMonitor.Tags = &newMonitor.Tags
       }
 
       return c.UpdateMonitor(ctx, updateMonitor)
@@ -148,7 +252,7 @@ func (c *Client) UpdateMonitor(ctx context.Context, updateMonitor UpdateMonitor)
   
   body, err := c.doRequest(req)
   if err != nil {
-   	return nil, errors.New(string(rb))
+   	return nil, fmt.Errorf("%w, request: %s", err, string(rb))
   }
   
   monitor := Monitor{}
@@ -160,29 +264,70 @@ func (c *Client) UpdateMonitor(ctx context.Context, updateMonitor UpdateMonitor)
   return &monitor, nil
 }
 
-func (c *Client) getDuplicatedMonitor(connectionId string, absolutePath string, monitorType string) (string, error) {
+// ListMonitors returns every monitor of a connection, including the disabled
+// ones.
+func (c *Client) ListMonitors(connectionId string) ([]Monitor, error) {
   req, err := http.NewRequest("GET", fmt.Sprintf("%s/monitors/connection/%s?includeDisabled=true", BaseUrl, connectionId), nil)
   if err != nil {
-  	return "", err
+  	return nil, err
   }
   
   body, err := c.doRequest(req)
   if err != nil {
-  	return "", err
+  	return nil, err
   }
   
   // Parse the response
   monitors := Monitors{}
   err = json.Unmarshal(body, &monitors)
   if err != nil {
-  	return "", err
+  	return nil, err
   }
   
-  for _, monitor := range monitors.Data {
-  	if strings.ToUpper(monitor.Type) == strings.ToUpper(monitorType) && strings.ToUpper(monitor.AbsolutePath) == strings.ToUpper(absolutePath) {
-      return monitor.ID, nil
+  return monitors.Data, nil
+}
+
+// FindMonitors returns the monitors of a connection with the given absolute
+// path and type. Both are compared case-insensitively.
+func (c *Client) FindMonitors(connectionId string, absolutePath string, monitorType string) ([]Monitor, error) {
+  monitors, err := c.ListMonitors(connectionId)
+  if err != nil {
+  	return nil, err
+  }
+  
+  matches := []Monitor{}
+  for _, monitor := range monitors {
+  	if strings.EqualFold(monitor.Type, monitorType) && strings.EqualFold(monitor.AbsolutePath, absolutePath) {
+      matches = append(matches, monitor)
   	}
   }
   
-  return "", errors.New("Monitor is not found")
+  return matches, nil
+}
+
+func (c *Client) getDuplicatedMonitor(connectionId string, absolutePath string, monitorType string) (string, error) {
+  monitors, err := c.FindMonitors(connectionId, absolutePath, monitorType)
+  if err != nil {
+  	return "", err
+  }
+  
+  if len(monitors) == 0 {
+    return "", errors.New("Monitor is not found")
+  }
+  
+  return monitors[0].ID, nil
+}
+
+// isOlder reports whether timestamp a is strictly before timestamp b. Values
+// that cannot be parsed are never considered older.
+func isOlder(a string, b string) bool {
+  ta, err := time.Parse(time.RFC3339Nano, a)
+  if err != nil {
+    return false
+  }
+  tb, err := time.Parse(time.RFC3339Nano, b)
+  if err != nil {
+    return false
+  }
+  return ta.Before(tb)
 }
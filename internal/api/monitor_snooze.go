@@ -0,0 +1,85 @@
+/*
+Implement CRUD for MonitorSnooze
+
+GetMonitorSnooze: built-in
+  Returns ErrNotFound when the monitor is not snoozed, including after the
+  snooze expired.
+SnoozeMonitor: built-in
+  Mutes the alerts of a monitor until the given time. Snoozing a snoozed
+  monitor replaces its snooze.
+UnsnoozeMonitor: built-in
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MonitorSnooze mutes the alerts of a monitor until a point in time. The
+// monitor keeps running while snoozed.
+type MonitorSnooze struct {
+	MonitorId string `json:"monitorId,omitempty"`
+	Until     string `json:"until"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+func (c *Client) GetMonitorSnooze(monitorId string) (*MonitorSnooze, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/monitors/%s/snooze", BaseUrl, monitorId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	snooze := MonitorSnooze{}
+	err = json.Unmarshal(body, &snooze)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snooze, nil
+}
+
+func (c *Client) SnoozeMonitor(monitorId string, snooze MonitorSnooze) (*MonitorSnooze, error) {
+	rb, err := json.Marshal(snooze)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/monitors/%s/snooze", BaseUrl, monitorId), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := MonitorSnooze{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) UnsnoozeMonitor(monitorId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/monitors/%s/snooze", BaseUrl, monitorId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
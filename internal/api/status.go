@@ -1,11 +1,18 @@
 package api
 
 import (
+  "context"
   "fmt"
   "encoding/json"
+  "errors"
   "net/http"
+  "time"
 )
 
+// monitorStatusPollInterval is how long WaitForMonitorStatus sleeps between
+// two calls to the status endpoint.
+const monitorStatusPollInterval = 10 * time.Second
+
 type MonitorStatus struct {
   Available    bool      `json:"available"`
   Type         string    `json:"type"`
@@ -15,9 +22,10 @@ type MonitorStatus struct {
   Predicted    float64   `json:"predicted"`
   Passed       bool      `json:"passed"`
   CreatedAt    string    `json:"createdAt"`
+  Error        *string   `json:"error,omitempty"`
 }
 
-func (c *Client) GetMonitorStatus(monitor_id string) (*Monitor, error) {
+func (c *Client) GetMonitorStatus(monitor_id string) (*MonitorStatus, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/monitors/status/%s", BaseUrl, monitor_id), nil)
 	if err != nil {
 		return nil, err
@@ -30,11 +38,60 @@ func (c *Client) GetMonitorStatus(monitor_id string) (*Monitor, error) {
 	}
 
   // Parse the response
-	monitor := Monitor{}
-	err = json.Unmarshal(body, &monitor)
+	status := MonitorStatus{}
+	err = json.Unmarshal(body, &status)
 	if err != nil {
 		return nil, err
 	}
-	return &monitor, nil
+	return &status, nil
 }
 
+// WaitForMonitorStatus polls the status endpoint until the first result of
+// the monitor is available, the timeout expires or the context is cancelled.
+// A run that finished with an error is reported as an error. Transient
+// errors, including a 404 while the status does not exist yet, keep the
+// polling going and the last one is returned when the timeout expires.
+func (c *Client) WaitForMonitorStatus(ctx context.Context, monitorId string, timeout time.Duration) (*MonitorStatus, error) {
+  ctx, cancel := context.WithTimeout(ctx, timeout)
+  defer cancel()
+
+  var lastErr error
+  for {
+    status, err := c.GetMonitorStatus(monitorId)
+    if err != nil && !isTransient(err) {
+      return nil, err
+    }
+    lastErr = err
+
+    if err == nil && status.Available {
+      if status.Error != nil && *status.Error != "" {
+        return status, fmt.Errorf("monitor %s run failed: %s", monitorId, *status.Error)
+      }
+      return status, nil
+    }
+
+    select {
+    case <-ctx.Done():
+      if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+        if lastErr != nil {
+          return nil, fmt.Errorf("monitor %s has no result after %s, last error: %w", monitorId, timeout, lastErr)
+        }
+        return nil, fmt.Errorf("monitor %s has no result after %s", monitorId, timeout)
+      }
+      return nil, ctx.Err()
+    case <-time.After(monitorStatusPollInterval):
+    }
+  }
+}
+
+// isTransient reports whether a failed status request may succeed later:
+// not found, rate limited, server and network errors. Other client errors,
+// such as an invalid API key, are fatal.
+func isTransient(err error) bool {
+  var statusErr *StatusError
+  if errors.As(err, &statusErr) {
+    return statusErr.Temporary()
+  }
+  var syntaxErr *json.SyntaxError
+  return !errors.As(err, &syntaxErr)
+}
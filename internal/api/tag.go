@@ -0,0 +1,153 @@
+/*
+Implement CRUD for Tag and TagAssignments
+
+GetTag: built-in
+CreateTag: built-in
+UpdateTag: built-in
+DeleteTag: built-in
+GetTagAssignments: built-in
+  Lists the monitors and catalog tables a tag is assigned to.
+SetTagAssignments: built-in
+  Replaces the monitors and catalog tables a tag is assigned to. Setting
+  empty lists removes the tag from everything.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Tag is both the request body and the response of the tag endpoints.
+type Tag struct {
+	ID          string  `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Color       *string `json:"color"`
+	Description *string `json:"description"`
+	CreatedAt   string  `json:"createdAt,omitempty"`
+	UpdatedAt   string  `json:"updatedAt,omitempty"`
+}
+
+// TaggedTable is a catalog table a tag is assigned to.
+type TaggedTable struct {
+	ConnectionId string `json:"connectionId"`
+	AbsolutePath string `json:"absolutePath"`
+}
+
+// TagAssignments lists what a tag is assigned to.
+type TagAssignments struct {
+	MonitorIds []string      `json:"monitorIds"`
+	Tables     []TaggedTable `json:"tables"`
+}
+
+func (c *Client) GetTag(tagId string) (*Tag, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tags/%s", BaseUrl, tagId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	tag := Tag{}
+	err = json.Unmarshal(body, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (c *Client) CreateTag(tag Tag) (*Tag, error) {
+	return c.writeTag(fmt.Sprintf("%s/tags", BaseUrl), tag)
+}
+
+func (c *Client) UpdateTag(tag Tag) (*Tag, error) {
+	return c.writeTag(fmt.Sprintf("%s/tags/%s", BaseUrl, tag.ID), tag)
+}
+
+func (c *Client) DeleteTag(tagId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/tags/%s", BaseUrl, tagId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeTag(url string, tag Tag) (*Tag, error) {
+	rb, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Tag{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) GetTagAssignments(tagId string) (*TagAssignments, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tags/%s/assignments", BaseUrl, tagId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := TagAssignments{}
+	err = json.Unmarshal(body, &assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignments, nil
+}
+
+func (c *Client) SetTagAssignments(tagId string, assignments TagAssignments) (*TagAssignments, error) {
+	rb, err := json.Marshal(assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tags/%s/assignments", BaseUrl, tagId), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := TagAssignments{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
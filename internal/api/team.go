@@ -0,0 +1,92 @@
+/*
+Implement CRUD for Team
+
+GetTeam: built-in
+CreateTeam: built-in
+UpdateTeam: built-in
+DeleteTeam: built-in
+  Deleting a team does not remove its members from the workspace.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Team is both the request body and the response of the team endpoints.
+type Team struct {
+	ID                        string   `json:"id,omitempty"`
+	Name                      string   `json:"name"`
+	MemberIds                 []string `json:"memberIds"`
+	DefaultAlertDestinationId *string  `json:"defaultAlertDestinationId"`
+	CreatedAt                 string   `json:"createdAt,omitempty"`
+	UpdatedAt                 string   `json:"updatedAt,omitempty"`
+}
+
+func (c *Client) GetTeam(teamId string) (*Team, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/teams/%s", BaseUrl, teamId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	team := Team{}
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+func (c *Client) CreateTeam(team Team) (*Team, error) {
+	return c.writeTeam(fmt.Sprintf("%s/teams", BaseUrl), team)
+}
+
+func (c *Client) UpdateTeam(team Team) (*Team, error) {
+	return c.writeTeam(fmt.Sprintf("%s/teams/%s", BaseUrl, team.ID), team)
+}
+
+func (c *Client) DeleteTeam(teamId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/teams/%s", BaseUrl, teamId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeTeam(url string, team Team) (*Team, error) {
+	rb, err := json.Marshal(team)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Team{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
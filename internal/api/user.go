@@ -0,0 +1,128 @@
+/*
+Implement CRUD for User
+
+GetUser: built-in
+GetUserByEmail: requires email
+  There is no GET method for a user by email. Instead, list all users and
+  match the email, case-insensitive.
+CreateUser: built-in
+  Invites the user to the workspace.
+UpdateUser: built-in
+DeleteUser: built-in
+  Removes the user from the workspace.
+*/
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// User roles, from most to least privileged.
+const (
+	UserRoleAdmin  = "ADMIN"
+	UserRoleEditor = "EDITOR"
+	UserRoleViewer = "VIEWER"
+)
+
+// User is both the request body and the response of the user endpoints.
+type User struct {
+	ID        string `json:"id,omitempty"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+func (c *Client) GetUser(userId string) (*User, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/users/%s", BaseUrl, userId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to the API
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response
+	user := User{}
+	err = json.Unmarshal(body, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (c *Client) GetUserByEmail(email string) (*User, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/users", BaseUrl), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	err = json.Unmarshal(body, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if strings.EqualFold(users[i].Email, email) {
+			return &users[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no user with email %s", ErrNotFound, email)
+}
+
+func (c *Client) CreateUser(user User) (*User, error) {
+	return c.writeUser(fmt.Sprintf("%s/users", BaseUrl), user)
+}
+
+func (c *Client) UpdateUser(user User) (*User, error) {
+	return c.writeUser(fmt.Sprintf("%s/users/%s", BaseUrl, user.ID), user)
+}
+
+func (c *Client) DeleteUser(userId string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/users/%s", BaseUrl, userId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) writeUser(url string, user User) (*User, error) {
+	rb, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := User{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,529 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &AlertDestinationResource{}
+	_ resource.ResourceWithConfigure        = &AlertDestinationResource{}
+	_ resource.ResourceWithImportState      = &AlertDestinationResource{}
+	_ resource.ResourceWithConfigValidators = &AlertDestinationResource{}
+	_ resource.ResourceWithModifyPlan       = &AlertDestinationResource{}
+)
+
+// NewAlertDestinationResource is a helper function to simplify the provider implementation.
+func NewAlertDestinationResource() resource.Resource {
+	return &AlertDestinationResource{}
+}
+
+// AlertDestinationResource is the resource implementation.
+type AlertDestinationResource struct {
+	client *api.Client
+}
+
+type AlertDestinationResourceModel struct {
+	Id        types.String               `tfsdk:"id"`
+	Name      types.String               `tfsdk:"name"`
+	Type      types.String               `tfsdk:"type"`
+	CreatedAt types.String               `tfsdk:"created_at"`
+	UpdatedAt types.String               `tfsdk:"updated_at"`
+	Slack     *SlackDestinationModel     `tfsdk:"slack"`
+	Email     *EmailDestinationModel     `tfsdk:"email"`
+	PagerDuty *PagerDutyDestinationModel `tfsdk:"pagerduty"`
+	Teams     *TeamsDestinationModel     `tfsdk:"teams"`
+	Webhook   *WebhookDestinationModel   `tfsdk:"webhook"`
+}
+
+type SlackDestinationModel struct {
+	Channel types.String `tfsdk:"channel"`
+}
+
+type EmailDestinationModel struct {
+	Addresses types.Set `tfsdk:"addresses"`
+}
+
+type PagerDutyDestinationModel struct {
+	IntegrationKey types.String `tfsdk:"integration_key"`
+	Severity       types.String `tfsdk:"severity"`
+}
+
+type TeamsDestinationModel struct {
+	WebhookUrl types.String `tfsdk:"webhook_url"`
+}
+
+type WebhookDestinationModel struct {
+	Url     types.String `tfsdk:"url"`
+	Secret  types.String `tfsdk:"secret"`
+	Headers types.Map    `tfsdk:"headers"`
+}
+
+// Metadata returns the resource type name.
+func (r *AlertDestinationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_destination"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AlertDestinationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *AlertDestinationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Alert destination resource. Exactly one of the `slack`, `email`, `pagerduty`, `teams` or `webhook` blocks must be set. " +
+			"Secrets are never returned by the API, so they are not refreshed and are unset after an import.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Alert destination identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Alert destination name",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of destination, derived from the destination block",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+			"slack": schema.SingleNestedAttribute{
+				MarkdownDescription: "Slack channel of the workspace connected to Metaplane",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"channel": schema.StringAttribute{
+						MarkdownDescription: "Channel name, e.g. `#data-alerts`",
+						Required:            true,
+					},
+				},
+			},
+			"email": schema.SingleNestedAttribute{
+				MarkdownDescription: "Email recipients",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"addresses": schema.SetAttribute{
+						MarkdownDescription: "Email addresses",
+						ElementType:         types.StringType,
+						Required:            true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+						},
+					},
+				},
+			},
+			"pagerduty": schema.SingleNestedAttribute{
+				MarkdownDescription: "PagerDuty service",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"integration_key": schema.StringAttribute{
+						MarkdownDescription: "Events API v2 integration key of the service",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"severity": schema.StringAttribute{
+						MarkdownDescription: "Severity of the incidents: `critical`, `error`, `warning` or `info`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("critical", "error", "warning", "info"),
+						},
+					},
+				},
+			},
+			"teams": schema.SingleNestedAttribute{
+				MarkdownDescription: "Microsoft Teams channel",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"webhook_url": schema.StringAttribute{
+						MarkdownDescription: "Incoming webhook URL of the channel",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"webhook": schema.SingleNestedAttribute{
+				MarkdownDescription: "Generic HTTPS webhook",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "URL the incidents are posted to",
+						Required:            true,
+					},
+					"secret": schema.StringAttribute{
+						MarkdownDescription: "Secret used to sign the payloads",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"headers": schema.MapAttribute{
+						MarkdownDescription: "Additional HTTP headers sent with every request",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one destination block.
+func (r *AlertDestinationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("slack"),
+			path.MatchRoot("email"),
+			path.MatchRoot("pagerduty"),
+			path.MatchRoot("teams"),
+			path.MatchRoot("webhook"),
+		),
+	}
+}
+
+// ModifyPlan derives the destination type from the destination block.
+// Changing the kind of destination replaces it.
+func (r *AlertDestinationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AlertDestinationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinationType := plan.destinationType()
+	if destinationType == "" {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), destinationType)...)
+		return
+	}
+
+	var state AlertDestinationResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API may report the type in another case, keep it unless the
+	// destination block changed
+	if !state.Type.IsNull() && strings.EqualFold(state.Type.ValueString(), destinationType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), state.Type)...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), destinationType)...)
+	if !state.Type.IsNull() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type"))
+	}
+}
+
+// destinationType returns the type of the destination block set in the model.
+func (m AlertDestinationResourceModel) destinationType() string {
+	switch {
+	case m.Slack != nil:
+		return api.AlertDestinationTypeSlack
+	case m.Email != nil:
+		return api.AlertDestinationTypeEmail
+	case m.PagerDuty != nil:
+		return api.AlertDestinationTypePagerDuty
+	case m.Teams != nil:
+		return api.AlertDestinationTypeTeams
+	case m.Webhook != nil:
+		return api.AlertDestinationTypeWebhook
+	}
+	return ""
+}
+
+// destination builds the API alert destination from the model.
+func (m AlertDestinationResourceModel) destination(ctx context.Context) (api.AlertDestination, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	destination := api.AlertDestination{
+		ID:   m.Id.ValueString(),
+		Name: m.Name.ValueString(),
+		Type: m.destinationType(),
+	}
+
+	switch {
+	case m.Slack != nil:
+		destination.Slack = &api.SlackDestination{
+			Channel: m.Slack.Channel.ValueString(),
+		}
+	case m.Email != nil:
+		addresses, d := stringSetElements(ctx, m.Email.Addresses)
+		diags.Append(d...)
+		destination.Email = &api.EmailDestination{
+			Addresses: addresses,
+		}
+	case m.PagerDuty != nil:
+		destination.PagerDuty = &api.PagerDutyDestination{
+			IntegrationKey: m.PagerDuty.IntegrationKey.ValueString(),
+			Severity:       m.PagerDuty.Severity.ValueStringPointer(),
+		}
+	case m.Teams != nil:
+		destination.Teams = &api.TeamsDestination{
+			WebhookUrl: m.Teams.WebhookUrl.ValueString(),
+		}
+	case m.Webhook != nil:
+		headers := map[string]string{}
+		if !m.Webhook.Headers.IsNull() {
+			diags.Append(m.Webhook.Headers.ElementsAs(ctx, &headers, false)...)
+		}
+		destination.Webhook = &api.WebhookDestination{
+			Url:     m.Webhook.Url.ValueString(),
+			Secret:  m.Webhook.Secret.ValueStringPointer(),
+			Headers: headers,
+		}
+	}
+
+	return destination, diags
+}
+
+// refresh maps the alert destination returned by the API onto the model.
+// Secrets are kept from the model since the API does not return them.
+func (m *AlertDestinationResourceModel) refresh(ctx context.Context, destination *api.AlertDestination) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.StringValue(destination.ID)
+	m.Name = types.StringValue(destination.Name)
+	m.Type = caseInsensitiveStringValue(destination.Type, m.Type)
+	m.CreatedAt = types.StringValue(destination.CreatedAt)
+	m.UpdatedAt = types.StringValue(destination.UpdatedAt)
+
+	if destination.Slack != nil {
+		m.Slack = &SlackDestinationModel{
+			Channel: types.StringValue(destination.Slack.Channel),
+		}
+	}
+	if destination.Email != nil {
+		prior := types.SetNull(types.StringType)
+		if m.Email != nil {
+			prior = m.Email.Addresses
+		}
+		addresses, d := stringSetValue(ctx, destination.Email.Addresses, prior)
+		diags.Append(d...)
+		m.Email = &EmailDestinationModel{
+			Addresses: addresses,
+		}
+	}
+	if destination.PagerDuty != nil {
+		if m.PagerDuty == nil {
+			m.PagerDuty = &PagerDutyDestinationModel{IntegrationKey: types.StringNull()}
+		}
+		m.PagerDuty.Severity = optionalStringValue(destination.PagerDuty.Severity, m.PagerDuty.Severity)
+	}
+	if destination.Teams != nil && m.Teams == nil {
+		m.Teams = &TeamsDestinationModel{WebhookUrl: types.StringNull()}
+	}
+	if destination.Webhook != nil {
+		if m.Webhook == nil {
+			m.Webhook = &WebhookDestinationModel{
+				Secret:  types.StringNull(),
+				Headers: types.MapNull(types.StringType),
+			}
+		}
+		m.Webhook.Url = types.StringValue(destination.Webhook.Url)
+	}
+
+	// Drop the blocks of other kinds, so that a type changed outside of
+	// Terraform shows up in the plan
+	destinationType := strings.ToUpper(destination.Type)
+	if destinationType != api.AlertDestinationTypeSlack {
+		m.Slack = nil
+	}
+	if destinationType != api.AlertDestinationTypeEmail {
+		m.Email = nil
+	}
+	if destinationType != api.AlertDestinationTypePagerDuty {
+		m.PagerDuty = nil
+	}
+	if destinationType != api.AlertDestinationTypeTeams {
+		m.Teams = nil
+	}
+	if destinationType != api.AlertDestinationTypeWebhook {
+		m.Webhook = nil
+	}
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AlertDestinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan AlertDestinationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	destination, diags := plan.destination(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new alert destination
+	created, err := r.client.CreateAlertDestination(destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating alert destination",
+			"Could not create alert destination, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AlertDestinationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AlertDestinationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed alert destination value from API
+	destination, err := r.client.GetAlertDestination(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Alert Destination",
+			"Could not read Metaplane Alert Destination ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, destination)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AlertDestinationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan AlertDestinationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	destination, diags := plan.destination(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing alert destination
+	updated, err := r.client.UpdateAlertDestination(destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating alert destination",
+			"Could not update alert destination, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AlertDestinationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AlertDestinationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAlertDestination(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Alert Destination",
+			"Could not delete alert destination, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *AlertDestinationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
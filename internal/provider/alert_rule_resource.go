@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &AlertRuleResource{}
+	_ resource.ResourceWithConfigure   = &AlertRuleResource{}
+	_ resource.ResourceWithImportState = &AlertRuleResource{}
+)
+
+// clockTimeRegexp matches a time of day such as "22:00".
+var clockTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// NewAlertRuleResource is a helper function to simplify the provider implementation.
+func NewAlertRuleResource() resource.Resource {
+	return &AlertRuleResource{}
+}
+
+// AlertRuleResource is the resource implementation.
+type AlertRuleResource struct {
+	client *api.Client
+}
+
+type AlertRuleResourceModel struct {
+	Id             types.String     `tfsdk:"id"`
+	Name           types.String     `tfsdk:"name"`
+	Enabled        types.Bool       `tfsdk:"enabled"`
+	ConnectionIds  types.Set        `tfsdk:"connection_ids"`
+	PathGlobs      types.Set        `tfsdk:"path_globs"`
+	MonitorTypes   types.Set        `tfsdk:"monitor_types"`
+	Tags           types.Set        `tfsdk:"tags"`
+	Priorities     types.Set        `tfsdk:"priorities"`
+	DestinationIds types.Set        `tfsdk:"destination_ids"`
+	QuietHours     *QuietHoursModel `tfsdk:"quiet_hours"`
+	CreatedAt      types.String     `tfsdk:"created_at"`
+	UpdatedAt      types.String     `tfsdk:"updated_at"`
+}
+
+type QuietHoursModel struct {
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Timezone types.String `tfsdk:"timezone"`
+	Days     types.Set    `tfsdk:"days"`
+}
+
+// Metadata returns the resource type name.
+func (r *AlertRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_rule"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AlertRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *AlertRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Alert rule resource. Routes the incidents of the matching monitors to alert destinations. " +
+			"A monitor matches when it matches every match attribute that is set, unset attributes match every monitor.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Alert rule identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Alert rule name",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alert rule is enabled. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"connection_ids": schema.SetAttribute{
+				MarkdownDescription: "Match monitors of these connections",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"path_globs": schema.SetAttribute{
+				MarkdownDescription: "Match monitors whose absolute path matches one of these globs, e.g. `ANALYTICS.FINANCE.*`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"monitor_types": schema.SetAttribute{
+				MarkdownDescription: "Match monitors of these types",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(sortedKeys(monitorTypes)...)),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Match monitors having one of these tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"priorities": schema.SetAttribute{
+				MarkdownDescription: "Match monitors of these priorities: `CRITICAL`, `HIGH`, `MEDIUM` or `LOW`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("CRITICAL", "HIGH", "MEDIUM", "LOW")),
+				},
+			},
+			"destination_ids": schema.SetAttribute{
+				MarkdownDescription: "Alert destinations the incidents are sent to",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"quiet_hours": schema.SingleNestedAttribute{
+				MarkdownDescription: "Daily window during which no alert is sent. The window wraps past midnight when `end` is before `start`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"start": schema.StringAttribute{
+						MarkdownDescription: "Start of the window, `HH:MM`",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(clockTimeRegexp, "value must be a time of day formatted as HH:MM"),
+						},
+					},
+					"end": schema.StringAttribute{
+						MarkdownDescription: "End of the window, `HH:MM`",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(clockTimeRegexp, "value must be a time of day formatted as HH:MM"),
+						},
+					},
+					"timezone": schema.StringAttribute{
+						MarkdownDescription: "IANA time zone of `start` and `end`, e.g. `America/New_York`",
+						Required:            true,
+						Validators: []validator.String{
+							timezoneValidator{},
+						},
+					},
+					"days": schema.SetAttribute{
+						MarkdownDescription: "Days the window applies to, `MON` to `SUN`. Every day when unset",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.OneOf("MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN")),
+						},
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// rule builds the API alert rule from the model.
+func (m AlertRuleResourceModel) rule(ctx context.Context) (api.AlertRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elements := func(set types.Set) []string {
+		values, d := stringSetElements(ctx, set)
+		diags.Append(d...)
+		return values
+	}
+
+	rule := api.AlertRule{
+		ID:        m.Id.ValueString(),
+		Name:      m.Name.ValueString(),
+		IsEnabled: m.Enabled.ValueBool(),
+		Match: api.AlertRuleMatch{
+			ConnectionIds: elements(m.ConnectionIds),
+			PathGlobs:     elements(m.PathGlobs),
+			MonitorTypes:  elements(m.MonitorTypes),
+			Tags:          elements(m.Tags),
+			Priorities:    elements(m.Priorities),
+		},
+		DestinationIds: elements(m.DestinationIds),
+	}
+	if m.QuietHours != nil {
+		rule.QuietHours = &api.QuietHours{
+			Start:    m.QuietHours.Start.ValueString(),
+			End:      m.QuietHours.End.ValueString(),
+			Timezone: m.QuietHours.Timezone.ValueString(),
+			Days:     elements(m.QuietHours.Days),
+		}
+	}
+
+	return rule, diags
+}
+
+// refresh maps the alert rule returned by the API onto the model.
+func (m *AlertRuleResourceModel) refresh(ctx context.Context, rule *api.AlertRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	setValue := func(values []string, prior types.Set) types.Set {
+		set, d := stringSetValue(ctx, values, prior)
+		diags.Append(d...)
+		return set
+	}
+
+	m.Id = types.StringValue(rule.ID)
+	m.Name = types.StringValue(rule.Name)
+	m.Enabled = types.BoolValue(rule.IsEnabled)
+	m.ConnectionIds = setValue(rule.Match.ConnectionIds, m.ConnectionIds)
+	m.PathGlobs = setValue(rule.Match.PathGlobs, m.PathGlobs)
+	m.MonitorTypes = setValue(rule.Match.MonitorTypes, m.MonitorTypes)
+	m.Tags = setValue(rule.Match.Tags, m.Tags)
+	m.Priorities = setValue(rule.Match.Priorities, m.Priorities)
+	m.DestinationIds = setValue(rule.DestinationIds, m.DestinationIds)
+	m.CreatedAt = types.StringValue(rule.CreatedAt)
+	m.UpdatedAt = types.StringValue(rule.UpdatedAt)
+
+	if rule.QuietHours == nil {
+		m.QuietHours = nil
+	} else {
+		prior := types.SetNull(types.StringType)
+		if m.QuietHours != nil {
+			prior = m.QuietHours.Days
+		}
+		m.QuietHours = &QuietHoursModel{
+			Start:    types.StringValue(rule.QuietHours.Start),
+			End:      types.StringValue(rule.QuietHours.End),
+			Timezone: types.StringValue(rule.QuietHours.Timezone),
+			Days:     setValue(rule.QuietHours.Days, prior),
+		}
+	}
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AlertRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan AlertRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	rule, diags := plan.rule(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new alert rule
+	created, err := r.client.CreateAlertRule(rule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating alert rule",
+			"Could not create alert rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AlertRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AlertRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed alert rule value from API
+	rule, err := r.client.GetAlertRule(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Alert Rule",
+			"Could not read Metaplane Alert Rule ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AlertRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan AlertRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	rule, diags := plan.rule(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing alert rule
+	updated, err := r.client.UpdateAlertRule(rule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating alert rule",
+			"Could not update alert rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AlertRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AlertRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAlertRule(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Alert Rule",
+			"Could not delete alert rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *AlertRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
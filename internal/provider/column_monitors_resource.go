@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ColumnMonitorsResource{}
+	_ resource.ResourceWithConfigure   = &ColumnMonitorsResource{}
+	_ resource.ResourceWithImportState = &ColumnMonitorsResource{}
+	_ resource.ResourceWithModifyPlan  = &ColumnMonitorsResource{}
+)
+
+// NewColumnMonitorsResource is a helper function to simplify the provider implementation.
+func NewColumnMonitorsResource() resource.Resource {
+	return &ColumnMonitorsResource{}
+}
+
+// ColumnMonitorsResource is the resource implementation.
+type ColumnMonitorsResource struct {
+	client *api.Client
+}
+
+type ColumnMonitorsResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	ConnectionId          types.String `tfsdk:"connection_id"`
+	AbsolutePath          types.String `tfsdk:"absolute_path"`
+	Columns               types.Map    `tfsdk:"columns"`
+	CronTab               types.String `tfsdk:"cron_tab"`
+	IncrementalColumnName types.String `tfsdk:"incremental_column_name"`
+	IncrementalDays       types.Int64  `tfsdk:"incremental_days"`
+	IncrementalHours      types.Int64  `tfsdk:"incremental_hours"`
+	IncrementalMinutes    types.Int64  `tfsdk:"incremental_minutes"`
+	MonitorIds            types.Map    `tfsdk:"monitor_ids"`
+	DisabledMonitorIds    types.Map    `tfsdk:"disabled_monitor_ids"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// Metadata returns the resource type name.
+func (r *ColumnMonitorsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_column_monitors"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ColumnMonitorsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *ColumnMonitorsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Column monitors of a table, one per column and monitor type. " +
+			"Removing a column or a type, or destroying the resource, disables the corresponding monitors.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the set, `{connection_id}:{absolute_path}`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"absolute_path": schema.StringAttribute{
+				MarkdownDescription: "{database}.{schema}.{table} of the table the columns belong to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.MapAttribute{
+				MarkdownDescription: "Monitor types keyed by column name. Supported types: " + strings.Join(monitorTypeNames("COLUMN"), ", "),
+				ElementType:         types.SetType{ElemType: types.StringType},
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueSetsAre(
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(stringvalidator.OneOf(monitorTypeNames("COLUMN")...)),
+					),
+				},
+			},
+			"cron_tab": schema.StringAttribute{
+				MarkdownDescription: "cron job schedule in * * * * * format, shared by all monitors",
+				Required:            true,
+			},
+			"incremental_column_name": schema.StringAttribute{
+				MarkdownDescription: "Incremental column name",
+				Optional:            true,
+			},
+			"incremental_days": schema.Int64Attribute{
+				MarkdownDescription: "Incremental days",
+				Optional:            true,
+			},
+			"incremental_hours": schema.Int64Attribute{
+				MarkdownDescription: "Incremental hours",
+				Optional:            true,
+			},
+			"incremental_minutes": schema.Int64Attribute{
+				MarkdownDescription: "Incremental minutes",
+				Optional:            true,
+			},
+			"monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Monitor identifiers keyed by `{column}:{type}`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"disabled_monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors the set disabled, keyed like `monitor_ids`. They are enabled again when desired again",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether the set may take over existing monitors with the same path and type that it does not manage. The plan shows a warning when it does. Adopted monitors are disabled once removed from the set. When false, such a collision fails the plan. Defaults to false",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// ModifyPlan looks for existing monitors the set does not manage with the
+// path and type of a planned monitor, since the apply would adopt them.
+func (r *ColumnMonitorsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ColumnMonitorsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ConnectionId.IsUnknown() || plan.AbsolutePath.IsUnknown() || plan.Columns.IsUnknown() {
+		return
+	}
+
+	current := monitorSetIds{}
+	if !req.State.Raw.IsNull() {
+		var state ColumnMonitorsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		current, diags = state.monitorIds(ctx)
+		resp.Diagnostics.Append(diags...)
+	}
+	// Elements may still be unknown, the apply checks again
+	desired, diags := plan.specs(ctx)
+	if resp.Diagnostics.HasError() || diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkMonitorCollisions(r.client, plan.ConnectionId.ValueString(), desired, current, plan.AdoptExisting.ValueBool())...)
+}
+
+// columnMonitorKey returns the key of the monitor of a column in the set.
+func columnMonitorKey(column string, monitorType string) string {
+	return column + ":" + monitorType
+}
+
+// splitColumnMonitorKey returns the column and the type of a key. Quoted
+// column names may contain ":" but monitor types never do, so the key is
+// split at its last ":".
+func splitColumnMonitorKey(key string) (string, string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
+// specs returns the desired monitors of the set keyed by column and type.
+func (m ColumnMonitorsResourceModel) specs(ctx context.Context) (map[string]monitorSpec, diag.Diagnostics) {
+	columns := map[string][]string{}
+	diags := m.Columns.ElementsAs(ctx, &columns, false)
+
+	specs := map[string]monitorSpec{}
+	for column, columnTypes := range columns {
+		for _, monitorType := range columnTypes {
+			spec := monitorSpec{
+				EntityType:   "COLUMN",
+				Type:         monitorType,
+				AbsolutePath: m.AbsolutePath.ValueString() + "." + column,
+				CronTab:      m.CronTab.ValueString(),
+			}
+			spec.Config.IncrementalClause = incrementalClause(m.IncrementalColumnName, m.IncrementalDays, m.IncrementalHours, m.IncrementalMinutes)
+			specs[columnMonitorKey(column, monitorType)] = spec
+		}
+	}
+	return specs, diags
+}
+
+// monitorIds returns the IDs of the monitors of the set keyed by column and type.
+func (m ColumnMonitorsResourceModel) monitorIds(ctx context.Context) (monitorSetIds, diag.Diagnostics) {
+	return newMonitorSetIds(ctx, m.MonitorIds, m.DisabledMonitorIds)
+}
+
+// setMonitorIds stores the IDs of the monitors of the set.
+func (m *ColumnMonitorsResourceModel) setMonitorIds(ctx context.Context, ids monitorSetIds) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(m.ConnectionId.ValueString() + ":" + m.AbsolutePath.ValueString())
+	m.MonitorIds, m.DisabledMonitorIds, diags = ids.values(ctx)
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ColumnMonitorsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ColumnMonitorsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := plan.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the monitors, saving the ones created so far on error
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), nil, desired, monitorSetIds{}, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating column monitors",
+			monitorSetError("create column monitors", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ColumnMonitorsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ColumnMonitorsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported set has no IDs yet, look the column monitors up on the table
+	if state.MonitorIds.IsNull() {
+		monitors, err := r.client.ListMonitors(state.ConnectionId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Metaplane Column Monitors",
+				"Could not list monitors of connection "+state.ConnectionId.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		prefix := state.AbsolutePath.ValueString() + "."
+		for _, monitor := range monitors {
+			if !monitor.IsEnabled || !strings.EqualFold(monitor.EntityType, "COLUMN") {
+				continue
+			}
+			// Compare the prefix in place, upper-casing may change its byte length
+			if len(monitor.AbsolutePath) <= len(prefix) || !strings.EqualFold(monitor.AbsolutePath[:len(prefix)], prefix) {
+				continue
+			}
+			column := monitor.AbsolutePath[len(prefix):]
+			current.Enabled[columnMonitorKey(column, strings.ToUpper(monitor.Type))] = monitor.ID
+		}
+	}
+
+	monitors, ids, err := readMonitors(r.client, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Column Monitors",
+			"Could not read monitors of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	columns := map[string][]string{}
+	for i, key := range sortedKeys(monitors) {
+		monitor := monitors[key]
+		column, monitorType := splitColumnMonitorKey(key)
+		columns[column] = append(columns[column], monitorType)
+		if monitor.CronTab != state.CronTab.ValueString() {
+			state.CronTab = types.StringValue(monitor.CronTab)
+		}
+		if i == 0 {
+			refreshIncrementalClause(monitor, &state.IncrementalColumnName, &state.IncrementalDays, &state.IncrementalHours, &state.IncrementalMinutes)
+		}
+	}
+
+	state.Columns, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, columns)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(state.setMonitorIds(ctx, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ColumnMonitorsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ColumnMonitorsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, diags := state.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	desired, diags := plan.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), previous, desired, current, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating column monitors",
+			monitorSetError("update column monitors", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables the monitors of the set and removes the Terraform state on success.
+func (r *ColumnMonitorsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ColumnMonitorsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := reconcileMonitors(ctx, r.client, state.ConnectionId.ValueString(), nil, nil, current, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Column Monitors",
+			"Could not disable column monitors, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a set by `{connection_id}:{absolute_path}`. The enabled
+// column monitors of the table become the set.
+func (r *ColumnMonitorsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	connectionId, absolutePath, ok := strings.Cut(req.ID, ":")
+	if !ok || connectionId == "" || absolutePath == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: connection_id:absolute_path. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), connectionId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("absolute_path"), absolutePath)...)
+}
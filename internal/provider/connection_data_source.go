@@ -3,15 +3,23 @@ package provider
 import (
   "context"
   "fmt"
+  "strings"
   
   "github.com/klaviyo/terraform-provider-metaplane/internal/api"
+  "github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+  "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
   "github.com/hashicorp/terraform-plugin-framework/types"
   "github.com/hashicorp/terraform-plugin-framework/datasource"
   "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+  "github.com/hashicorp/terraform-plugin-framework/path"
+  "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &ConnectionDataSource{}
+var (
+  _ datasource.DataSource                     = &ConnectionDataSource{}
+  _ datasource.DataSourceWithConfigValidators = &ConnectionDataSource{}
+)
 
 // ConnectionDataSource defines the data source implementation.
 type ConnectionDataSource struct {
@@ -24,6 +32,7 @@ func NewConnectionDataSource() datasource.DataSource {
 
 type ConnectionDataSourceModel struct {
 	Name           types.String    `tfsdk:"name"`
+  NameRegex      types.String    `tfsdk:"name_regex"`
 	ConnectionId   types.String    `tfsdk:"id"`
   Type           types.String    `tfsdk:"type"`
   IsEnabled      types.Bool      `tfsdk:"is_enabled"`
@@ -38,19 +47,36 @@ func (d *ConnectionDataSource) Metadata(ctx context.Context, req datasource.Meta
 
 func (d *ConnectionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Connection data source",
+		MarkdownDescription: "Connection data source. Looks up the only connection matching every selector set among `id`, `name`, `name_regex` and `type`. " +
+      "Fails, listing the candidates, when no connection or more than one connection matches.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Connection name",
-				Required:            true,
+				MarkdownDescription: "Connection name, matched exactly",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the connection name matches, e.g. `^snowflake-prod$`",
+				Optional:            true,
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Connection identifier",
+				Optional:            true,
 				Computed:            true,
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of connection, row_count, etc",
+				MarkdownDescription: "Type of connection, e.g. `SNOWFLAKE`, case-insensitive",
+				Optional:            true,
 				Computed:            true,
+        Validators: []validator.String{
+          stringvalidator.OneOfCaseInsensitive(
+            api.ConnectionTypeSnowflake,
+            api.ConnectionTypeBigQuery,
+            api.ConnectionTypeRedshift,
+            api.ConnectionTypePostgres,
+            api.ConnectionTypeDatabricks,
+          ),
+        },
 			},
 			"is_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Example identifier",
@@ -72,6 +98,18 @@ func (d *ConnectionDataSource) Schema(ctx context.Context, req datasource.Schema
 	}
 }
 
+// ConfigValidators requires at least one selector.
+func (d *ConnectionDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+  return []datasource.ConfigValidator{
+    datasourcevalidator.AtLeastOneOf(
+      path.MatchRoot("id"),
+      path.MatchRoot("name"),
+      path.MatchRoot("name_regex"),
+      path.MatchRoot("type"),
+    ),
+  }
+}
+
 func (d *ConnectionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -101,25 +139,52 @@ func (d *ConnectionDataSource) Read(ctx context.Context, req datasource.ReadRequ
     return
   }
 
-  name := state.Name.ValueString()
-
-  connection, err := d.client.GetConnection(name)
+  nameRegex, diags := compileNameRegex(state.NameRegex)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+    return
+  }
 
+  connections, err := d.client.ListConnections()
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read connection, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list connections, got error: %s", err))
 		return
 	}
 
+  filter := connectionFilter{
+    connectionId:   state.ConnectionId,
+    name:           state.Name,
+    nameRegex:      nameRegex,
+    connectionType: state.Type,
+  }
+  matches := filter.filter(connections)
+  if len(matches) == 0 {
+    resp.Diagnostics.AddError("Connection Not Found", "No connection matches the selectors.")
+    return
+  }
+  if len(matches) > 1 {
+    candidates := make([]string, 0, len(matches))
+    for _, match := range matches {
+      candidates = append(candidates, fmt.Sprintf("%q (id %s, type %s)", match.Name, match.ConnectionId, match.Type))
+    }
+    resp.Diagnostics.AddError(
+      "Ambiguous Connection",
+      fmt.Sprintf("%d connections match the selectors, narrow them down to one of: %s.", len(matches), strings.Join(candidates, ", ")),
+    )
+    return
+  }
+  connection := matches[0]
+
   state.Name         = types.StringValue(connection.Name)
   state.ConnectionId = types.StringValue(connection.ConnectionId)
-  state.Type         = types.StringValue(connection.Type)
+  state.Type         = caseInsensitiveStringValue(connection.Type, state.Type)
   state.IsEnabled    = types.BoolValue  (connection.IsEnabled)
   state.UpdatedAt    = types.StringValue(connection.UpdatedAt)
   state.CreatedAt    = types.StringValue(connection.CreatedAt)
   state.Status       = types.StringValue(connection.Status)
 
   // Set state
-  diags := resp.State.Set(ctx, &state)
+  diags = resp.State.Set(ctx, &state)
   resp.Diagnostics.Append(diags...)
   if resp.Diagnostics.HasError() {
     return;
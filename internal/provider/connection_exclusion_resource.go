@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gopath "path"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ConnectionExclusionResource{}
+	_ resource.ResourceWithConfigure      = &ConnectionExclusionResource{}
+	_ resource.ResourceWithImportState    = &ConnectionExclusionResource{}
+	_ resource.ResourceWithValidateConfig = &ConnectionExclusionResource{}
+)
+
+// NewConnectionExclusionResource is a helper function to simplify the provider implementation.
+func NewConnectionExclusionResource() resource.Resource {
+	return &ConnectionExclusionResource{}
+}
+
+// ConnectionExclusionResource is the resource implementation.
+type ConnectionExclusionResource struct {
+	client *api.Client
+}
+
+type ConnectionExclusionResourceModel struct {
+	Id            types.String       `tfsdk:"id"`
+	ConnectionId  types.String       `tfsdk:"connection_id"`
+	PatternSyntax types.String       `tfsdk:"pattern_syntax"`
+	Databases     *PatternRulesModel `tfsdk:"databases"`
+	Schemas       *PatternRulesModel `tfsdk:"schemas"`
+	Tables        *PatternRulesModel `tfsdk:"tables"`
+}
+
+type PatternRulesModel struct {
+	Include types.Set `tfsdk:"include"`
+	Exclude types.Set `tfsdk:"exclude"`
+}
+
+// Metadata returns the resource type name.
+func (r *ConnectionExclusionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_exclusion"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ConnectionExclusionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// patternRulesAttribute returns the schema of the rules of one kind of
+// object, matched against the given form of name.
+func patternRulesAttribute(objects string, name string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("Rules for %s, matched against `%s`", objects, name),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"include": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("Only discover the %s matching one of these patterns. Every one when unset", objects),
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"exclude": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("Never discover the %s matching one of these patterns", objects),
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *ConnectionExclusionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connection exclusion resource. Limits what Metaplane discovers and monitors on a connection, e.g. to leave out scratch and dbt dev schemas. " +
+			"There is one set of rules per connection, destroying the resource removes them. Patterns are case-insensitive.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the rules, the connection ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pattern_syntax": schema.StringAttribute{
+				MarkdownDescription: "Syntax of the patterns: `GLOB`, e.g. `DBT_*`, or `REGEX`, e.g. `^DBT_.*$`. Defaults to `GLOB`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(api.PatternSyntaxGlob),
+				Validators: []validator.String{
+					stringvalidator.OneOf(api.PatternSyntaxGlob, api.PatternSyntaxRegex),
+				},
+			},
+			"databases": patternRulesAttribute("databases", "DATABASE"),
+			"schemas":   patternRulesAttribute("schemas", "DATABASE.SCHEMA"),
+			"tables":    patternRulesAttribute("tables", "DATABASE.SCHEMA.TABLE"),
+		},
+	}
+}
+
+// ValidateConfig checks the syntax of every pattern.
+func (r *ConnectionExclusionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ConnectionExclusionResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PatternSyntax.IsUnknown() {
+		return
+	}
+	syntax := config.PatternSyntax.ValueString()
+	if config.PatternSyntax.IsNull() {
+		syntax = api.PatternSyntaxGlob
+	}
+
+	rules := map[string]*PatternRulesModel{
+		"databases": config.Databases,
+		"schemas":   config.Schemas,
+		"tables":    config.Tables,
+	}
+	for _, name := range sortedKeys(rules) {
+		if rules[name] == nil {
+			continue
+		}
+		sets := map[string]types.Set{
+			"include": rules[name].Include,
+			"exclude": rules[name].Exclude,
+		}
+		for _, list := range sortedKeys(sets) {
+			if sets[list].IsNull() || sets[list].IsUnknown() {
+				continue
+			}
+
+			patterns := []types.String{}
+			resp.Diagnostics.Append(sets[list].ElementsAs(ctx, &patterns, false)...)
+			for _, pattern := range patterns {
+				if pattern.IsUnknown() {
+					continue
+				}
+				if err := validatePattern(syntax, pattern.ValueString()); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name).AtName(list),
+						"Invalid Pattern",
+						fmt.Sprintf("%q is not a valid %s pattern: %s.", pattern.ValueString(), syntax, err),
+					)
+				}
+			}
+		}
+	}
+}
+
+// validatePattern checks the syntax of a pattern.
+func validatePattern(syntax string, pattern string) error {
+	if pattern == "" {
+		return errors.New("pattern is empty")
+	}
+	if syntax == api.PatternSyntaxRegex {
+		_, err := regexp.Compile(pattern)
+		return err
+	}
+	_, err := gopath.Match(pattern, "")
+	return err
+}
+
+// exclusions builds the API exclusion rules from the model.
+func (m ConnectionExclusionResourceModel) exclusions(ctx context.Context) (api.ConnectionExclusions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	rules := func(model *PatternRulesModel) api.PatternRules {
+		result := api.PatternRules{Include: []string{}, Exclude: []string{}}
+		if model == nil {
+			return result
+		}
+		var d diag.Diagnostics
+		result.Include, d = stringSetElements(ctx, model.Include)
+		diags.Append(d...)
+		result.Exclude, d = stringSetElements(ctx, model.Exclude)
+		diags.Append(d...)
+		return result
+	}
+
+	return api.ConnectionExclusions{
+		PatternSyntax: m.PatternSyntax.ValueString(),
+		Databases:     rules(m.Databases),
+		Schemas:       rules(m.Schemas),
+		Tables:        rules(m.Tables),
+	}, diags
+}
+
+// refresh maps the exclusion rules returned by the API onto the model.
+func (m *ConnectionExclusionResourceModel) refresh(ctx context.Context, connectionId string, exclusions *api.ConnectionExclusions) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rules := func(result api.PatternRules, prior *PatternRulesModel) *PatternRulesModel {
+		if len(result.Include) == 0 && len(result.Exclude) == 0 && prior == nil {
+			return nil
+		}
+		model := &PatternRulesModel{
+			Include: types.SetNull(types.StringType),
+			Exclude: types.SetNull(types.StringType),
+		}
+		if prior != nil {
+			model.Include = prior.Include
+			model.Exclude = prior.Exclude
+		}
+		var d diag.Diagnostics
+		model.Include, d = stringSetValue(ctx, result.Include, model.Include)
+		diags.Append(d...)
+		model.Exclude, d = stringSetValue(ctx, result.Exclude, model.Exclude)
+		diags.Append(d...)
+		return model
+	}
+
+	m.Id = types.StringValue(connectionId)
+	m.ConnectionId = types.StringValue(connectionId)
+	m.PatternSyntax = types.StringValue(exclusions.PatternSyntax)
+	m.Databases = rules(exclusions.Databases, m.Databases)
+	m.Schemas = rules(exclusions.Schemas, m.Schemas)
+	m.Tables = rules(exclusions.Tables, m.Tables)
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ConnectionExclusionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionExclusionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	exclusions, diags := plan.exclusions(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the exclusion rules of the connection
+	connectionId := plan.ConnectionId.ValueString()
+	result, err := r.client.SetConnectionExclusions(connectionId, exclusions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connection exclusion",
+			"Could not set exclusion rules of connection "+connectionId+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, connectionId, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ConnectionExclusionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ConnectionExclusionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed exclusion rules from API
+	exclusions, err := r.client.GetConnectionExclusions(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Connection Exclusion",
+			"Could not read exclusion rules of Metaplane Connection ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, state.Id.ValueString(), exclusions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ConnectionExclusionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionExclusionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	exclusions, diags := plan.exclusions(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Replace the exclusion rules of the connection
+	connectionId := plan.ConnectionId.ValueString()
+	result, err := r.client.SetConnectionExclusions(connectionId, exclusions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating connection exclusion",
+			"Could not set exclusion rules of connection "+connectionId+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, connectionId, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the exclusion rules and the Terraform state on success.
+func (r *ConnectionExclusionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ConnectionExclusionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	empty := api.PatternRules{Include: []string{}, Exclude: []string{}}
+	exclusions := api.ConnectionExclusions{
+		PatternSyntax: api.PatternSyntaxGlob,
+		Databases:     empty,
+		Schemas:       empty,
+		Tables:        empty,
+	}
+	_, err := r.client.SetConnectionExclusions(state.Id.ValueString(), exclusions)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Connection Exclusion",
+			"Could not remove exclusion rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConnectionExclusionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,528 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &ConnectionResource{}
+	_ resource.ResourceWithConfigure        = &ConnectionResource{}
+	_ resource.ResourceWithImportState      = &ConnectionResource{}
+	_ resource.ResourceWithConfigValidators = &ConnectionResource{}
+	_ resource.ResourceWithModifyPlan       = &ConnectionResource{}
+)
+
+// NewConnectionResource is a helper function to simplify the provider implementation.
+func NewConnectionResource() resource.Resource {
+	return &ConnectionResource{}
+}
+
+// ConnectionResource is the resource implementation.
+type ConnectionResource struct {
+	client *api.Client
+}
+
+type ConnectionResourceModel struct {
+	ConnectionId types.String                `tfsdk:"id"`
+	Name         types.String                `tfsdk:"name"`
+	Type         types.String                `tfsdk:"type"`
+	Enabled      types.Bool                  `tfsdk:"enabled"`
+	Status       types.String                `tfsdk:"status"`
+	CreatedAt    types.String                `tfsdk:"created_at"`
+	UpdatedAt    types.String                `tfsdk:"updated_at"`
+	Snowflake    *SnowflakeCredentialsModel  `tfsdk:"snowflake"`
+	BigQuery     *BigQueryCredentialsModel   `tfsdk:"bigquery"`
+	Redshift     *DatabaseCredentialsModel   `tfsdk:"redshift"`
+	Postgres     *DatabaseCredentialsModel   `tfsdk:"postgres"`
+	Databricks   *DatabricksCredentialsModel `tfsdk:"databricks"`
+}
+
+type SnowflakeCredentialsModel struct {
+	Account    types.String `tfsdk:"account"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	PrivateKey types.String `tfsdk:"private_key"`
+	Warehouse  types.String `tfsdk:"warehouse"`
+	Role       types.String `tfsdk:"role"`
+	Database   types.String `tfsdk:"database"`
+}
+
+type BigQueryCredentialsModel struct {
+	ProjectId         types.String `tfsdk:"project_id"`
+	ServiceAccountKey types.String `tfsdk:"service_account_key"`
+	Location          types.String `tfsdk:"location"`
+}
+
+type DatabaseCredentialsModel struct {
+	Host     types.String `tfsdk:"host"`
+	Port     types.Int64  `tfsdk:"port"`
+	Database types.String `tfsdk:"database"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+type DatabricksCredentialsModel struct {
+	Host     types.String `tfsdk:"host"`
+	HttpPath types.String `tfsdk:"http_path"`
+	Token    types.String `tfsdk:"token"`
+	Catalog  types.String `tfsdk:"catalog"`
+}
+
+// Metadata returns the resource type name.
+func (r *ConnectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ConnectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// databaseCredentialsAttributes are shared by the Redshift and Postgres
+// credential blocks, which only differ by their default port.
+func databaseCredentialsAttributes(defaultPort int64) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"host": schema.StringAttribute{
+			MarkdownDescription: "Host name of the cluster",
+			Required:            true,
+		},
+		"port": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Port of the cluster. Defaults to %d", defaultPort),
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(defaultPort),
+		},
+		"database": schema.StringAttribute{
+			MarkdownDescription: "Database to connect to",
+			Required:            true,
+		},
+		"username": schema.StringAttribute{
+			MarkdownDescription: "User Metaplane connects as",
+			Required:            true,
+		},
+		"password": schema.StringAttribute{
+			MarkdownDescription: "Password of the user",
+			Required:            true,
+			Sensitive:           true,
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *ConnectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connection resource. Exactly one of the `snowflake`, `bigquery`, `redshift`, `postgres` or `databricks` " +
+			"credential blocks must be set. Secrets are never returned by the API, so they are not refreshed and are unset after an import.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Connection name",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of connection, derived from the credential block",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the connection is enabled. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Connection status",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+			"snowflake": schema.SingleNestedAttribute{
+				MarkdownDescription: "Snowflake credentials",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"account": schema.StringAttribute{
+						MarkdownDescription: "Account identifier, e.g. `xy12345.us-east-1`",
+						Required:            true,
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "User Metaplane connects as",
+						Required:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "Password of the user, conflicts with `private_key`",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("private_key")),
+						},
+					},
+					"private_key": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded private key for key pair authentication, conflicts with `password`",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"warehouse": schema.StringAttribute{
+						MarkdownDescription: "Warehouse used to run monitor queries",
+						Optional:            true,
+					},
+					"role": schema.StringAttribute{
+						MarkdownDescription: "Role used to run monitor queries",
+						Optional:            true,
+					},
+					"database": schema.StringAttribute{
+						MarkdownDescription: "Database to limit the connection to",
+						Optional:            true,
+					},
+				},
+			},
+			"bigquery": schema.SingleNestedAttribute{
+				MarkdownDescription: "BigQuery credentials",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"project_id": schema.StringAttribute{
+						MarkdownDescription: "Project identifier",
+						Required:            true,
+					},
+					"service_account_key": schema.StringAttribute{
+						MarkdownDescription: "JSON key of the service account Metaplane connects as",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"location": schema.StringAttribute{
+						MarkdownDescription: "Location of the datasets, e.g. `US`",
+						Optional:            true,
+					},
+				},
+			},
+			"redshift": schema.SingleNestedAttribute{
+				MarkdownDescription: "Redshift credentials",
+				Optional:            true,
+				Attributes:          databaseCredentialsAttributes(5439),
+			},
+			"postgres": schema.SingleNestedAttribute{
+				MarkdownDescription: "Postgres credentials",
+				Optional:            true,
+				Attributes:          databaseCredentialsAttributes(5432),
+			},
+			"databricks": schema.SingleNestedAttribute{
+				MarkdownDescription: "Databricks credentials",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Host name of the workspace",
+						Required:            true,
+					},
+					"http_path": schema.StringAttribute{
+						MarkdownDescription: "HTTP path of the SQL warehouse",
+						Required:            true,
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "Personal access token",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"catalog": schema.StringAttribute{
+						MarkdownDescription: "Unity catalog to limit the connection to",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one credential block.
+func (r *ConnectionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("snowflake"),
+			path.MatchRoot("bigquery"),
+			path.MatchRoot("redshift"),
+			path.MatchRoot("postgres"),
+			path.MatchRoot("databricks"),
+		),
+	}
+}
+
+// ModifyPlan derives the connection type from the credential block. Changing
+// the warehouse replaces the connection.
+func (r *ConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ConnectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionType, _ := plan.credentials()
+	if connectionType == "" {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), connectionType)...)
+		return
+	}
+
+	var state ConnectionResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API may report the type in another case, keep it unless the
+	// credential block changed
+	if !state.Type.IsNull() && strings.EqualFold(state.Type.ValueString(), connectionType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), state.Type)...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), connectionType)...)
+	if !state.Type.IsNull() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type"))
+	}
+}
+
+// credentials returns the connection type and the API credentials of the
+// credential block set in the model.
+func (m ConnectionResourceModel) credentials() (string, api.ConnectionCredentials) {
+	switch {
+	case m.Snowflake != nil:
+		return api.ConnectionTypeSnowflake, api.ConnectionCredentials{
+			Snowflake: &api.SnowflakeCredentials{
+				Account:    m.Snowflake.Account.ValueString(),
+				Username:   m.Snowflake.Username.ValueString(),
+				Password:   m.Snowflake.Password.ValueStringPointer(),
+				PrivateKey: m.Snowflake.PrivateKey.ValueStringPointer(),
+				Warehouse:  m.Snowflake.Warehouse.ValueStringPointer(),
+				Role:       m.Snowflake.Role.ValueStringPointer(),
+				Database:   m.Snowflake.Database.ValueStringPointer(),
+			},
+		}
+	case m.BigQuery != nil:
+		return api.ConnectionTypeBigQuery, api.ConnectionCredentials{
+			BigQuery: &api.BigQueryCredentials{
+				ProjectId:         m.BigQuery.ProjectId.ValueString(),
+				ServiceAccountKey: m.BigQuery.ServiceAccountKey.ValueString(),
+				Location:          m.BigQuery.Location.ValueStringPointer(),
+			},
+		}
+	case m.Redshift != nil:
+		return api.ConnectionTypeRedshift, api.ConnectionCredentials{
+			Redshift: m.Redshift.credentials(),
+		}
+	case m.Postgres != nil:
+		return api.ConnectionTypePostgres, api.ConnectionCredentials{
+			Postgres: m.Postgres.credentials(),
+		}
+	case m.Databricks != nil:
+		return api.ConnectionTypeDatabricks, api.ConnectionCredentials{
+			Databricks: &api.DatabricksCredentials{
+				Host:     m.Databricks.Host.ValueString(),
+				HttpPath: m.Databricks.HttpPath.ValueString(),
+				Token:    m.Databricks.Token.ValueString(),
+				Catalog:  m.Databricks.Catalog.ValueStringPointer(),
+			},
+		}
+	}
+	return "", api.ConnectionCredentials{}
+}
+
+func (m DatabaseCredentialsModel) credentials() *api.DatabaseCredentials {
+	return &api.DatabaseCredentials{
+		Host:     m.Host.ValueString(),
+		Port:     m.Port.ValueInt64(),
+		Database: m.Database.ValueString(),
+		Username: m.Username.ValueString(),
+		Password: m.Password.ValueString(),
+	}
+}
+
+// refresh maps the connection returned by the API onto the model. The
+// credentials are left untouched since the API does not return them.
+func (m *ConnectionResourceModel) refresh(connection *api.Connection) {
+	m.ConnectionId = types.StringValue(connection.ConnectionId)
+	m.Name = types.StringValue(connection.Name)
+	m.Type = caseInsensitiveStringValue(connection.Type, m.Type)
+	m.Enabled = types.BoolValue(connection.IsEnabled)
+	m.Status = types.StringValue(connection.Status)
+	m.CreatedAt = types.StringValue(connection.CreatedAt)
+	m.UpdatedAt = types.StringValue(connection.UpdatedAt)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	connectionType, credentials := plan.credentials()
+	newConnection := api.NewConnection{
+		Name:        plan.Name.ValueString(),
+		Type:        connectionType,
+		IsEnabled:   plan.Enabled.ValueBool(),
+		Credentials: credentials,
+	}
+
+	// Create new connection
+	connection, err := r.client.CreateConnection(newConnection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connection",
+			"Could not create connection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.refresh(connection)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ConnectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed connection value from API
+	connection, err := r.client.GetConnectionById(state.ConnectionId.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Connection",
+			"Could not read Metaplane Connection ID "+state.ConnectionId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.refresh(connection)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	_, credentials := plan.credentials()
+	updateConnection := api.UpdateConnection{
+		ConnectionId: plan.ConnectionId.ValueString(),
+		Name:         plan.Name.ValueString(),
+		IsEnabled:    plan.Enabled.ValueBoolPointer(),
+		Credentials:  &credentials,
+	}
+
+	// Update existing connection
+	connection, err := r.client.UpdateConnection(updateConnection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating connection",
+			"Could not update connection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.refresh(connection)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ConnectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteConnection(state.ConnectionId.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Connection",
+			"Could not delete connection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ConnectionSyncSettingsResource{}
+	_ resource.ResourceWithConfigure      = &ConnectionSyncSettingsResource{}
+	_ resource.ResourceWithImportState    = &ConnectionSyncSettingsResource{}
+	_ resource.ResourceWithValidateConfig = &ConnectionSyncSettingsResource{}
+)
+
+// NewConnectionSyncSettingsResource is a helper function to simplify the provider implementation.
+func NewConnectionSyncSettingsResource() resource.Resource {
+	return &ConnectionSyncSettingsResource{}
+}
+
+// ConnectionSyncSettingsResource is the resource implementation.
+type ConnectionSyncSettingsResource struct {
+	client *api.Client
+}
+
+type ConnectionSyncSettingsResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	ConnectionId        types.String `tfsdk:"connection_id"`
+	SyncFrequency       types.String `tfsdk:"sync_frequency"`
+	QueryHistoryEnabled types.Bool   `tfsdk:"query_history_enabled"`
+	Warehouse           types.String `tfsdk:"warehouse"`
+	Role                types.String `tfsdk:"role"`
+	LastSyncedAt        types.String `tfsdk:"last_synced_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *ConnectionSyncSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_sync_settings"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ConnectionSyncSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *ConnectionSyncSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connection sync settings resource. Controls how often Metaplane syncs the metadata of an existing connection and which compute runs its monitor queries, " +
+			"e.g. to cap the Snowflake credits Metaplane uses. There is one set of settings per connection, settings left unset keep their current value " +
+			"and destroying the resource restores the Metaplane defaults.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the settings, the connection ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sync_frequency": schema.StringAttribute{
+				MarkdownDescription: "How often metadata is synced, a duration in whole minutes, e.g. `6h`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"query_history_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the query history of the warehouse is ingested, used for lineage and usage",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"warehouse": schema.StringAttribute{
+				MarkdownDescription: "Warehouse running the monitor queries, Snowflake connections only",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role running the monitor queries, Snowflake connections only",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"last_synced_at": schema.StringAttribute{
+				MarkdownDescription: "datetime of the last metadata sync",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the sync frequency is a whole number of
+// minutes, the precision of the API.
+func (r *ConnectionSyncSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ConnectionSyncSettingsResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.SyncFrequency.IsNull() || config.SyncFrequency.IsUnknown() {
+		return
+	}
+	frequency, err := time.ParseDuration(config.SyncFrequency.ValueString())
+	if err == nil && frequency%time.Minute != 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sync_frequency"),
+			"Invalid Sync Frequency",
+			fmt.Sprintf("sync_frequency must be a whole number of minutes, got: %q", config.SyncFrequency.ValueString()),
+		)
+	}
+}
+
+// settings builds the API sync settings from the model. Unknown values are
+// left unset so that they keep their current value.
+func (m ConnectionSyncSettingsResourceModel) settings() api.ConnectionSyncSettings {
+	settings := api.ConnectionSyncSettings{}
+	if !m.QueryHistoryEnabled.IsNull() && !m.QueryHistoryEnabled.IsUnknown() {
+		settings.QueryHistoryEnabled = m.QueryHistoryEnabled.ValueBoolPointer()
+	}
+	if !m.Warehouse.IsNull() && !m.Warehouse.IsUnknown() {
+		settings.Warehouse = m.Warehouse.ValueStringPointer()
+	}
+	if !m.Role.IsNull() && !m.Role.IsUnknown() {
+		settings.Role = m.Role.ValueStringPointer()
+	}
+	if frequency, err := time.ParseDuration(m.SyncFrequency.ValueString()); err == nil {
+		minutes := int64(frequency / time.Minute)
+		settings.SyncIntervalMinutes = &minutes
+	}
+	return settings
+}
+
+// refresh maps the sync settings returned by the API onto the model.
+func (m *ConnectionSyncSettingsResourceModel) refresh(connectionId string, settings *api.ConnectionSyncSettings) {
+	m.Id = types.StringValue(connectionId)
+	m.ConnectionId = types.StringValue(connectionId)
+	m.SyncFrequency = durationMinutesValue(settings.SyncIntervalMinutes, m.SyncFrequency)
+	m.QueryHistoryEnabled = types.BoolPointerValue(settings.QueryHistoryEnabled)
+	// Snowflake identifiers are case-insensitive and returned uppercase
+	warehouse := optionalStringValue(settings.Warehouse, m.Warehouse)
+	if !warehouse.IsNull() {
+		warehouse = caseInsensitiveStringValue(warehouse.ValueString(), m.Warehouse)
+	}
+	m.Warehouse = warehouse
+	role := optionalStringValue(settings.Role, m.Role)
+	if !role.IsNull() {
+		role = caseInsensitiveStringValue(role.ValueString(), m.Role)
+	}
+	m.Role = role
+	m.LastSyncedAt = timestampValue(settings.LastSyncedAt, m.LastSyncedAt)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ConnectionSyncSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionSyncSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the sync settings of the connection
+	connectionId := plan.ConnectionId.ValueString()
+	result, err := r.client.SetConnectionSyncSettings(connectionId, plan.settings())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connection sync settings",
+			"Could not set sync settings of connection "+connectionId+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.refresh(connectionId, result)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ConnectionSyncSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ConnectionSyncSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed sync settings from API
+	settings, err := r.client.GetConnectionSyncSettings(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Connection Sync Settings",
+			"Could not read sync settings of Metaplane Connection ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.refresh(state.Id.ValueString(), settings)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ConnectionSyncSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan ConnectionSyncSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update the sync settings of the connection
+	connectionId := plan.ConnectionId.ValueString()
+	result, err := r.client.SetConnectionSyncSettings(connectionId, plan.settings())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating connection sync settings",
+			"Could not set sync settings of connection "+connectionId+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.refresh(connectionId, result)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete restores the default sync settings and removes the Terraform state on success.
+func (r *ConnectionSyncSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ConnectionSyncSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ResetConnectionSyncSettings(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Connection Sync Settings",
+			"Could not restore default sync settings, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ConnectionSyncSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConnectionsDataSource{}
+
+func NewConnectionsDataSource() datasource.DataSource {
+	return &ConnectionsDataSource{}
+}
+
+// ConnectionsDataSource defines the data source implementation.
+type ConnectionsDataSource struct {
+	client *api.Client
+}
+
+type ConnectionsDataSourceModel struct {
+	Type        types.String      `tfsdk:"type"`
+	Status      types.String      `tfsdk:"status"`
+	IsEnabled   types.Bool        `tfsdk:"is_enabled"`
+	NameRegex   types.String      `tfsdk:"name_regex"`
+	Connections []ConnectionModel `tfsdk:"connections"`
+}
+
+// ConnectionModel holds the attributes of a connection reported by the API.
+type ConnectionModel struct {
+	Name         types.String `tfsdk:"name"`
+	ConnectionId types.String `tfsdk:"id"`
+	Type         types.String `tfsdk:"type"`
+	IsEnabled    types.Bool   `tfsdk:"is_enabled"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Status       types.String `tfsdk:"status"`
+}
+
+// newConnectionModel maps a connection returned by the API.
+func newConnectionModel(connection api.Connection) ConnectionModel {
+	return ConnectionModel{
+		Name:         types.StringValue(connection.Name),
+		ConnectionId: types.StringValue(connection.ConnectionId),
+		Type:         types.StringValue(connection.Type),
+		IsEnabled:    types.BoolValue(connection.IsEnabled),
+		UpdatedAt:    types.StringValue(connection.UpdatedAt),
+		CreatedAt:    types.StringValue(connection.CreatedAt),
+		Status:       types.StringValue(connection.Status),
+	}
+}
+
+// connectionFilter selects connections. Unset fields match every connection,
+// the type and status are compared case-insensitively.
+type connectionFilter struct {
+	connectionId   types.String
+	name           types.String
+	nameRegex      *regexp.Regexp
+	connectionType types.String
+	status         types.String
+	isEnabled      types.Bool
+}
+
+// matches reports whether the connection matches every field set.
+func (f connectionFilter) matches(connection api.Connection) bool {
+	if !f.connectionId.IsNull() && f.connectionId.ValueString() != connection.ConnectionId {
+		return false
+	}
+	if !f.name.IsNull() && f.name.ValueString() != connection.Name {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(connection.Name) {
+		return false
+	}
+	if !f.connectionType.IsNull() && !strings.EqualFold(f.connectionType.ValueString(), connection.Type) {
+		return false
+	}
+	if !f.status.IsNull() && !strings.EqualFold(f.status.ValueString(), connection.Status) {
+		return false
+	}
+	return f.isEnabled.IsNull() || f.isEnabled.ValueBool() == connection.IsEnabled
+}
+
+// filter returns the matching connections, sorted by name.
+func (f connectionFilter) filter(connections []api.Connection) []api.Connection {
+	matches := []api.Connection{}
+	for _, connection := range connections {
+		if f.matches(connection) {
+			matches = append(matches, connection)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// compileNameRegex compiles the name_regex attribute, nil when unset.
+func compileNameRegex(value types.String) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		return nil, diags
+	}
+	nameRegex, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid Regular Expression",
+			fmt.Sprintf("%q is not a valid regular expression: %s.", value.ValueString(), err),
+		)
+	}
+	return nameRegex, diags
+}
+
+func (d *ConnectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connections"
+}
+
+func (d *ConnectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connections data source. Lists the connections matching every filter set, sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list connections of this type, e.g. `SNOWFLAKE`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(
+						api.ConnectionTypeSnowflake,
+						api.ConnectionTypeBigQuery,
+						api.ConnectionTypeRedshift,
+						api.ConnectionTypePostgres,
+						api.ConnectionTypeDatabricks,
+					),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list connections with this status, case-insensitive",
+				Optional:            true,
+			},
+			"is_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Only list enabled, or disabled, connections",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list connections whose name matches this regular expression",
+				Optional:            true,
+			},
+			"connections": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching connections",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Connection name",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Connection identifier",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of connection, e.g. `SNOWFLAKE`",
+							Computed:            true,
+						},
+						"is_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the connection is enabled",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "datetime updated",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "datetime created",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Connection status",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ConnectionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(state.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connections, err := d.client.ListConnections()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list connections, got error: %s", err))
+		return
+	}
+
+	filter := connectionFilter{
+		nameRegex:      nameRegex,
+		connectionType: state.Type,
+		status:         state.Status,
+		isEnabled:      state.IsEnabled,
+	}
+	state.Connections = []ConnectionModel{}
+	for _, connection := range filter.filter(connections) {
+		state.Connections = append(state.Connections, newConnectionModel(connection))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
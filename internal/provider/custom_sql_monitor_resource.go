@@ -0,0 +1,481 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &CustomSqlMonitorResource{}
+	_ resource.ResourceWithConfigure      = &CustomSqlMonitorResource{}
+	_ resource.ResourceWithImportState    = &CustomSqlMonitorResource{}
+	_ resource.ResourceWithValidateConfig = &CustomSqlMonitorResource{}
+)
+
+// NewCustomSqlMonitorResource is a helper function to simplify the provider implementation.
+func NewCustomSqlMonitorResource() resource.Resource {
+	return &CustomSqlMonitorResource{}
+}
+
+// CustomSqlMonitorResource is the resource implementation.
+type CustomSqlMonitorResource struct {
+	client *api.Client
+}
+
+type CustomSqlMonitorResourceModel struct {
+	Id           types.String  `tfsdk:"id"`
+	ConnectionId types.String  `tfsdk:"connection_id"`
+	AbsolutePath types.String  `tfsdk:"absolute_path"`
+	Sql          types.String  `tfsdk:"sql"`
+	ResultMode   types.String  `tfsdk:"result_mode"`
+	ResultColumn types.String  `tfsdk:"result_column"`
+	LowerBound   types.Float64 `tfsdk:"lower_bound"`
+	UpperBound   types.Float64 `tfsdk:"upper_bound"`
+	Sensitivity  types.String  `tfsdk:"sensitivity"`
+	CronTab      types.String  `tfsdk:"cron_tab"`
+	Name         types.String  `tfsdk:"name"`
+	Description  types.String  `tfsdk:"description"`
+	Priority     types.String  `tfsdk:"priority"`
+	CreatedAt    types.String  `tfsdk:"created_at"`
+	UpdatedAt    types.String  `tfsdk:"updated_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *CustomSqlMonitorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_sql_monitor"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CustomSqlMonitorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *CustomSqlMonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom SQL monitor resource. Runs a read-only query on a schedule and alerts on its result. " +
+			"The query is checked at plan time: it must be a single, non-empty statement without DML, DDL, procedure, session or stage keywords. " +
+			"Monitors cannot be deleted, destroying the resource disables the monitor. Existing monitors are never taken over, import them instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Monitor identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"absolute_path": schema.StringAttribute{
+				MarkdownDescription: "Table the monitor is attached to, `DATABASE.SCHEMA.TABLE`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sql": schema.StringAttribute{
+				MarkdownDescription: "Query run by the monitor",
+				Required:            true,
+				Validators: []validator.String{
+					customSqlValidator{},
+				},
+			},
+			"result_mode": schema.StringAttribute{
+				MarkdownDescription: "How the result is checked: `ZERO_ROWS` alerts when the query returns rows, " +
+					"`THRESHOLD` when the result is outside of `lower_bound` and `upper_bound`, " +
+					"`ANOMALY` when the anomaly model flags the result",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(api.CustomSqlResultZeroRows, api.CustomSqlResultThreshold, api.CustomSqlResultAnomaly),
+				},
+			},
+			"result_column": schema.StringAttribute{
+				MarkdownDescription: "Column holding the result when the query returns several columns. Not used with `ZERO_ROWS`",
+				Optional:            true,
+			},
+			"lower_bound": schema.Float64Attribute{
+				MarkdownDescription: "Lowest accepted result, `THRESHOLD` only",
+				Optional:            true,
+			},
+			"upper_bound": schema.Float64Attribute{
+				MarkdownDescription: "Highest accepted result, `THRESHOLD` only",
+				Optional:            true,
+			},
+			"sensitivity": schema.StringAttribute{
+				MarkdownDescription: "Sensitivity of the anomaly model: `LOW`, `MEDIUM` or `HIGH`, `ANOMALY` only",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("LOW", "MEDIUM", "HIGH"),
+				},
+			},
+			"cron_tab": schema.StringAttribute{
+				MarkdownDescription: "cron job schedule in * * * * * format",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the monitor",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the monitor",
+				Optional:            true,
+			},
+			"priority": schema.StringAttribute{
+				MarkdownDescription: "Priority of the monitor: `CRITICAL`, `HIGH`, `MEDIUM` or `LOW`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("CRITICAL", "HIGH", "MEDIUM", "LOW"),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the attributes used by the result mode.
+func (r *CustomSqlMonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CustomSqlMonitorResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ResultMode.IsNull() || config.ResultMode.IsUnknown() {
+		return
+	}
+	mode := config.ResultMode.ValueString()
+
+	notUsed := func(attribute string, value interface{ IsNull() bool }) {
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Custom SQL Monitor",
+				fmt.Sprintf("%s is not used with result_mode %s.", attribute, mode),
+			)
+		}
+	}
+
+	switch mode {
+	case api.CustomSqlResultZeroRows:
+		notUsed("result_column", config.ResultColumn)
+		notUsed("lower_bound", config.LowerBound)
+		notUsed("upper_bound", config.UpperBound)
+		notUsed("sensitivity", config.Sensitivity)
+	case api.CustomSqlResultThreshold:
+		notUsed("sensitivity", config.Sensitivity)
+		if config.LowerBound.IsNull() && config.UpperBound.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("result_mode"),
+				"Invalid Custom SQL Monitor",
+				"result_mode THRESHOLD requires lower_bound, upper_bound or both.",
+			)
+		}
+		if !config.LowerBound.IsNull() && !config.LowerBound.IsUnknown() && !config.UpperBound.IsNull() && !config.UpperBound.IsUnknown() &&
+			config.LowerBound.ValueFloat64() > config.UpperBound.ValueFloat64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lower_bound"),
+				"Invalid Custom SQL Monitor",
+				fmt.Sprintf("lower_bound %v must not be greater than upper_bound %v.", config.LowerBound.ValueFloat64(), config.UpperBound.ValueFloat64()),
+			)
+		}
+	case api.CustomSqlResultAnomaly:
+		notUsed("lower_bound", config.LowerBound)
+		notUsed("upper_bound", config.UpperBound)
+	}
+}
+
+// config builds the API monitor configuration from the model.
+func (m CustomSqlMonitorResourceModel) config() api.Config {
+	config := api.Config{
+		CustomSql:    m.Sql.ValueStringPointer(),
+		ResultMode:   m.ResultMode.ValueStringPointer(),
+		ResultColumn: m.ResultColumn.ValueStringPointer(),
+	}
+
+	switch m.ResultMode.ValueString() {
+	case api.CustomSqlResultThreshold:
+		config.Threshold = &api.Threshold{
+			Type:       api.ThresholdTypeStatic,
+			LowerBound: m.LowerBound.ValueFloat64Pointer(),
+			UpperBound: m.UpperBound.ValueFloat64Pointer(),
+		}
+	case api.CustomSqlResultAnomaly:
+		config.Threshold = &api.Threshold{
+			Type: api.ThresholdTypeML,
+		}
+		config.Sensitivity = m.Sensitivity.ValueStringPointer()
+	}
+
+	return config
+}
+
+// fields returns the attributes of the model shared with the monitor
+// resource and data sources, see MonitorModel.
+func (m *CustomSqlMonitorResourceModel) fields() monitorFields {
+	return monitorFields{
+		MonitorId:    &m.Id,
+		ConnectionId: &m.ConnectionId,
+		AbsolutePath: &m.AbsolutePath,
+		CronTab:      &m.CronTab,
+		CreatedAt:    &m.CreatedAt,
+		UpdatedAt:    &m.UpdatedAt,
+		CustomSql:    &m.Sql,
+		LowerBound:   &m.LowerBound,
+		UpperBound:   &m.UpperBound,
+		Sensitivity:  &m.Sensitivity,
+		Name:         &m.Name,
+		Description:  &m.Description,
+		Priority:     &m.Priority,
+	}
+}
+
+// refresh maps the monitor returned by the API onto the model like the other
+// monitors, and then the attributes specific to custom SQL monitors.
+func (m *CustomSqlMonitorResourceModel) refresh(ctx context.Context, monitor *api.Monitor) diag.Diagnostics {
+	prior := m.Sql
+	diags := m.fields().refresh(ctx, monitor)
+
+	config := monitor.Config
+	if config == nil {
+		config = &api.Config{}
+	}
+
+	// Keep the configured query when the API only trimmed it
+	if !prior.IsNull() && !prior.IsUnknown() && strings.TrimSpace(m.Sql.ValueString()) == strings.TrimSpace(prior.ValueString()) {
+		m.Sql = prior
+	}
+
+	// Older monitors have no result mode, derive it from the threshold
+	mode := api.CustomSqlResultZeroRows
+	switch {
+	case config.ResultMode != nil:
+		mode = *config.ResultMode
+	case config.Threshold != nil && strings.EqualFold(config.Threshold.Type, api.ThresholdTypeStatic):
+		mode = api.CustomSqlResultThreshold
+	case config.Threshold != nil:
+		mode = api.CustomSqlResultAnomaly
+	}
+	m.ResultMode = caseInsensitiveStringValue(mode, m.ResultMode)
+	m.ResultColumn = optionalStringValue(config.ResultColumn, m.ResultColumn)
+
+	// Only static thresholds have bounds to configure
+	if config.Threshold == nil || !strings.EqualFold(config.Threshold.Type, api.ThresholdTypeStatic) {
+		m.LowerBound = types.Float64Null()
+		m.UpperBound = types.Float64Null()
+	}
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *CustomSqlMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan CustomSqlMonitorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new monitor
+	newMonitor := api.NewMonitor{
+		ConnectionId: plan.ConnectionId.ValueString(),
+		Type:         "CUSTOM_SQL",
+		EntityType:   "TABLE",
+		CronTab:      plan.CronTab.ValueString(),
+		AbsolutePath: plan.AbsolutePath.ValueString(),
+		Config:       plan.config(),
+		Name:         plan.Name.ValueStringPointer(),
+		Description:  plan.Description.ValueStringPointer(),
+		Priority:     plan.Priority.ValueStringPointer(),
+	}
+	// Existing monitors are never adopted, a table may have several custom
+	// SQL monitors and the one reported as the duplicate is arbitrary
+	monitor, err := r.client.CreateMonitor(ctx, newMonitor, false)
+	if errors.Is(err, api.ErrMonitorExists) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("absolute_path"),
+			"Monitor already exists",
+			err.Error()+". Import it with `terraform import` instead.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating custom SQL monitor",
+			"Could not create custom SQL monitor, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Re-read the monitor so that the state holds what the API persisted
+	monitor, err = r.client.GetMonitorAfterWrite(ctx, monitor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading custom SQL monitor",
+			"Could not read custom SQL monitor "+newMonitor.AbsolutePath+" after create, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, monitor)...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *CustomSqlMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CustomSqlMonitorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed monitor value from API. A disabled monitor is gone as far
+	// as Terraform is concerned, the next apply enables it again.
+	monitor, err := r.client.GetMonitor(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) || (err == nil && !monitor.IsEnabled) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Custom SQL Monitor",
+			"Could not read Metaplane Monitor ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, monitor)...)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *CustomSqlMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan CustomSqlMonitorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	description := plan.Description.ValueString()
+	priority := plan.Priority.ValueString()
+
+	// Update existing monitor
+	updateMonitor := api.UpdateMonitor{
+		MonitorId:   plan.Id.ValueString(),
+		CronTab:     plan.CronTab.ValueString(),
+		IsEnabled:   true,
+		Config:      plan.config(),
+		Name:        &name,
+		Description: &description,
+		Priority:    &priority,
+	}
+	monitor, err := r.client.UpdateMonitor(ctx, updateMonitor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating custom SQL monitor",
+			"Could not update custom SQL monitor, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Re-read the monitor so that the state holds what the API persisted
+	monitor, err = r.client.GetMonitorAfterWrite(ctx, monitor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading custom SQL monitor",
+			"Could not read custom SQL monitor "+updateMonitor.MonitorId+" after update, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, monitor)...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete disables the monitor and removes the Terraform state on success.
+func (r *CustomSqlMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CustomSqlMonitorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateMonitor := api.UpdateMonitor{
+		MonitorId: state.Id.ValueString(),
+		IsEnabled: false,
+	}
+	_, err := r.client.UpdateMonitor(ctx, updateMonitor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Custom SQL Monitor",
+			"Could not disable monitor, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *CustomSqlMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
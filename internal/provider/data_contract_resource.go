@@ -0,0 +1,526 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Statuses of a data contract.
+const (
+	contractStatusPending  = "PENDING"
+	contractStatusPassing  = "PASSING"
+	contractStatusViolated = "VIOLATED"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DataContractResource{}
+	_ resource.ResourceWithConfigure        = &DataContractResource{}
+	_ resource.ResourceWithConfigValidators = &DataContractResource{}
+	_ resource.ResourceWithModifyPlan       = &DataContractResource{}
+)
+
+// NewDataContractResource is a helper function to simplify the provider implementation.
+func NewDataContractResource() resource.Resource {
+	return &DataContractResource{}
+}
+
+// DataContractResource is the resource implementation.
+type DataContractResource struct {
+	client *api.Client
+}
+
+type DataContractResourceModel struct {
+	Id                 types.String                   `tfsdk:"id"`
+	ConnectionId       types.String                   `tfsdk:"connection_id"`
+	AbsolutePath       types.String                   `tfsdk:"absolute_path"`
+	Columns            map[string]ContractColumnModel `tfsdk:"columns"`
+	FreshnessSla       types.String                   `tfsdk:"freshness_sla"`
+	MinRowCount        types.Int64                    `tfsdk:"min_row_count"`
+	CronTab            types.String                   `tfsdk:"cron_tab"`
+	Status             types.String                   `tfsdk:"status"`
+	Violations         types.List                     `tfsdk:"violations"`
+	MonitorIds         types.Map                      `tfsdk:"monitor_ids"`
+	DisabledMonitorIds types.Map                      `tfsdk:"disabled_monitor_ids"`
+	AdoptExisting      types.Bool                     `tfsdk:"adopt_existing"`
+}
+
+type ContractColumnModel struct {
+	Type     types.String `tfsdk:"type"`
+	Nullable types.Bool   `tfsdk:"nullable"`
+}
+
+// Metadata returns the resource type name.
+func (r *DataContractResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_contract"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DataContractResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *DataContractResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data contract resource. Declares the expectations on a table and enforces them with monitors: " +
+			"a schema change monitor when `columns` is set, a nullness monitor per non-nullable column, " +
+			"a freshness monitor for `freshness_sla` and a row count monitor for `min_row_count`. " +
+			"`status` and `violations` report the state of the contract on every refresh. " +
+			"Monitors cannot be deleted, destroying the resource disables them. The contract cannot be imported.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the contract, `{connection_id}:{absolute_path}`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"absolute_path": schema.StringAttribute{
+				MarkdownDescription: "Table of the contract, `DATABASE.SCHEMA.TABLE`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.MapNestedAttribute{
+				MarkdownDescription: "Expected columns keyed by name",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Expected warehouse type, e.g. `NUMBER` or `VARCHAR(256)`, case-insensitive. " +
+								"A type without precision matches any precision. Any type when unset",
+							Optional: true,
+						},
+						"nullable": schema.BoolAttribute{
+							MarkdownDescription: "`false` requires the column to hold no null values. Not checked when unset",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"freshness_sla": schema.StringAttribute{
+				MarkdownDescription: "Maximum age of the data, e.g. `6h`",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"min_row_count": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of rows",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"cron_tab": schema.StringAttribute{
+				MarkdownDescription: "cron job schedule of the checks in * * * * * format",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "`PASSING`, `VIOLATED`, or `PENDING` until every check has a monitor that ran once",
+				Computed:            true,
+			},
+			"violations": schema.ListAttribute{
+				MarkdownDescription: "Descriptions of the expectations that are not met",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors enforcing the contract",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"disabled_monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors the contract disabled, keyed like `monitor_ids`. They are enabled again when desired again",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether the contract may take over existing monitors with the same path and type that it does not manage. The plan shows a warning when it does. Adopted monitors are disabled once no longer needed by the contract. When false, such a collision fails the plan. Defaults to false",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires at least one expectation.
+func (r *DataContractResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("columns"),
+			path.MatchRoot("freshness_sla"),
+			path.MatchRoot("min_row_count"),
+		),
+	}
+}
+
+// ModifyPlan plans an update when monitors of the contract were disabled or
+// deleted outside of Terraform, so that the apply brings them back. It also
+// looks for existing monitors the contract does not manage with the path and
+// type of a planned monitor, since the apply would adopt them.
+func (r *DataContractResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DataContractResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := monitorSetIds{}
+	if !req.State.Raw.IsNull() {
+		var state DataContractResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids, diags := state.monitorIds(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current = ids
+
+		if !plan.MonitorIds.IsUnknown() && !reflect.DeepEqual(sortedKeys(ids.Enabled), sortedKeys(state.specs())) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monitor_ids"), types.MapUnknown(types.StringType))...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("disabled_monitor_ids"), types.MapUnknown(types.StringType))...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("violations"), types.ListUnknown(types.StringType))...)
+		}
+	}
+
+	if r.client == nil || plan.ConnectionId.IsUnknown() || plan.AbsolutePath.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(checkMonitorCollisions(r.client, plan.ConnectionId.ValueString(), plan.specs(), current, plan.AdoptExisting.ValueBool())...)
+}
+
+// specs returns the monitors enforcing the contract. Table monitors are keyed
+// by type and column monitors like in metaplane_column_monitors.
+func (m DataContractResourceModel) specs() map[string]monitorSpec {
+	specs := map[string]monitorSpec{}
+	tableSpec := func(monitorType string, threshold *api.Threshold) monitorSpec {
+		return monitorSpec{
+			EntityType:   "TABLE",
+			Type:         monitorType,
+			AbsolutePath: m.AbsolutePath.ValueString(),
+			CronTab:      m.CronTab.ValueString(),
+			Config:       api.Config{Threshold: threshold},
+		}
+	}
+
+	if len(m.Columns) > 0 {
+		specs["SCHEMA_CHANGE"] = tableSpec("SCHEMA_CHANGE", nil)
+	}
+	if !m.FreshnessSla.IsNull() {
+		// Freshness results are the age of the data in seconds
+		sla, _ := time.ParseDuration(m.FreshnessSla.ValueString())
+		specs["FRESHNESS"] = tableSpec("FRESHNESS", &api.Threshold{
+			Type:       api.ThresholdTypeStatic,
+			UpperBound: float64Ptr(sla.Seconds()),
+		})
+	}
+	if !m.MinRowCount.IsNull() {
+		specs["ROW_COUNT"] = tableSpec("ROW_COUNT", &api.Threshold{
+			Type:       api.ThresholdTypeStatic,
+			LowerBound: float64Ptr(float64(m.MinRowCount.ValueInt64())),
+		})
+	}
+	for _, column := range sortedKeys(m.Columns) {
+		if m.Columns[column].Nullable.IsNull() || m.Columns[column].Nullable.ValueBool() {
+			continue
+		}
+		// Nullness results are the percentage of null values
+		specs[columnMonitorKey(column, "NULLNESS")] = monitorSpec{
+			EntityType:   "COLUMN",
+			Type:         "NULLNESS",
+			AbsolutePath: m.AbsolutePath.ValueString() + "." + column,
+			CronTab:      m.CronTab.ValueString(),
+			Config: api.Config{Threshold: &api.Threshold{
+				Type:       api.ThresholdTypeStatic,
+				UpperBound: float64Ptr(0),
+			}},
+		}
+	}
+	return specs
+}
+
+// monitorIds returns the IDs of the monitors of the contract.
+func (m DataContractResourceModel) monitorIds(ctx context.Context) (monitorSetIds, diag.Diagnostics) {
+	return newMonitorSetIds(ctx, m.MonitorIds, m.DisabledMonitorIds)
+}
+
+// setMonitorIds stores the IDs of the monitors of the contract.
+func (m *DataContractResourceModel) setMonitorIds(ctx context.Context, ids monitorSetIds) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(m.ConnectionId.ValueString() + ":" + m.AbsolutePath.ValueString())
+	m.MonitorIds, m.DisabledMonitorIds, diags = ids.values(ctx)
+	return diags
+}
+
+// evaluate checks the contract against the catalog and the latest results of
+// its monitors, and sets its status and violations.
+func (r *DataContractResource) evaluate(ctx context.Context, m *DataContractResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	violations := []string{}
+	pending := false
+
+	if len(m.Columns) > 0 {
+		columns, err := r.client.ListColumns(m.ConnectionId.ValueString(), m.AbsolutePath.ValueString())
+		switch {
+		case errors.Is(err, api.ErrNotFound):
+			violations = append(violations, fmt.Sprintf("table %s is missing", m.AbsolutePath.ValueString()))
+		case err != nil:
+			diags.AddError(
+				"Error Reading Metaplane Data Contract",
+				"Could not list the columns of "+m.AbsolutePath.ValueString()+": "+err.Error(),
+			)
+			return diags
+		default:
+			violations = append(violations, columnViolations(m.Columns, columns)...)
+		}
+	}
+
+	// Checks whose monitor was disabled or deleted have no result until the
+	// next apply brings the monitor back.
+	setIds, d := m.monitorIds(ctx)
+	diags.Append(d...)
+	ids := setIds.Enabled
+	for key := range m.specs() {
+		if _, ok := ids[key]; !ok {
+			pending = true
+		}
+	}
+	for _, key := range sortedKeys(ids) {
+		status, err := r.client.GetMonitorStatus(ids[key])
+		if errors.Is(err, api.ErrNotFound) || (err == nil && !status.Available) {
+			pending = true
+			continue
+		}
+		if err != nil {
+			diags.AddError(
+				"Error Reading Metaplane Data Contract",
+				"Could not read the status of monitor "+key+": "+err.Error(),
+			)
+			return diags
+		}
+		if !status.Passed {
+			violations = append(violations, fmt.Sprintf("%s check failed: result %v, expected between %v and %v", key, status.Result, status.LowerBound, status.UpperBound))
+		}
+	}
+
+	switch {
+	case len(violations) > 0:
+		m.Status = types.StringValue(contractStatusViolated)
+	case pending:
+		m.Status = types.StringValue(contractStatusPending)
+	default:
+		m.Status = types.StringValue(contractStatusPassing)
+	}
+	m.Violations, d = types.ListValueFrom(ctx, types.StringType, violations)
+	diags.Append(d...)
+
+	return diags
+}
+
+// columnViolations compares the expected columns with the ones of the table.
+func columnViolations(expected map[string]ContractColumnModel, columns []api.Column) []string {
+	violations := []string{}
+	for _, name := range sortedKeys(expected) {
+		var column *api.Column
+		for i := range columns {
+			if strings.EqualFold(columns[i].Name, name) {
+				column = &columns[i]
+				break
+			}
+		}
+
+		if column == nil {
+			violations = append(violations, fmt.Sprintf("column %s is missing", name))
+			continue
+		}
+		expectedType := expected[name].Type
+		if !expectedType.IsNull() && !columnTypeMatches(expectedType.ValueString(), column.Type) {
+			violations = append(violations, fmt.Sprintf("column %s has type %s, expected %s", name, column.Type, expectedType.ValueString()))
+		}
+	}
+	return violations
+}
+
+// columnTypeMatches compares warehouse types case-insensitively. An expected
+// type without precision matches any precision.
+func columnTypeMatches(expected string, actual string) bool {
+	if strings.EqualFold(expected, actual) {
+		return true
+	}
+	if strings.Contains(expected, "(") {
+		return false
+	}
+	base, _, _ := strings.Cut(actual, "(")
+	return strings.EqualFold(expected, strings.TrimSpace(base))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *DataContractResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan DataContractResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the monitors, saving the ones created so far on error
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), nil, plan.specs(), monitorSetIds{}, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating data contract",
+			monitorSetError("create the monitors of the contract", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	plan.Status = types.StringValue(contractStatusPending)
+	plan.Violations = types.ListValueMust(types.StringType, nil)
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.evaluate(ctx, &plan)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. Monitors that were
+// disabled outside of Terraform move to the disabled ones and deleted ones
+// are dropped, so that the next plan brings them back.
+func (r *DataContractResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DataContractResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, ids, err := readMonitors(r.client, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Data Contract",
+			"Could not read monitors of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(r.evaluate(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DataContractResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DataContractResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), state.specs(), plan.specs(), current, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating data contract",
+			monitorSetError("update the monitors of the contract", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	plan.Status = state.Status
+	plan.Violations = state.Violations
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.evaluate(ctx, &plan)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables the monitors of the contract and removes the Terraform state on success.
+func (r *DataContractResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DataContractResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := reconcileMonitors(ctx, r.client, state.ConnectionId.ValueString(), nil, nil, current, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Data Contract",
+			"Could not disable the monitors of the contract, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DbtIntegrationResource{}
+	_ resource.ResourceWithConfigure   = &DbtIntegrationResource{}
+	_ resource.ResourceWithImportState = &DbtIntegrationResource{}
+)
+
+// NewDbtIntegrationResource is a helper function to simplify the provider implementation.
+func NewDbtIntegrationResource() resource.Resource {
+	return &DbtIntegrationResource{}
+}
+
+// DbtIntegrationResource is the resource implementation.
+type DbtIntegrationResource struct {
+	client *api.Client
+}
+
+type DbtIntegrationResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	ConnectionId        types.String `tfsdk:"connection_id"`
+	AccountId           types.Int64  `tfsdk:"account_id"`
+	ProjectId           types.Int64  `tfsdk:"project_id"`
+	JobIds              types.Set    `tfsdk:"job_ids"`
+	ApiToken            types.String `tfsdk:"api_token"`
+	IngestTests         types.Bool   `tfsdk:"ingest_tests"`
+	IngestModelMetadata types.Bool   `tfsdk:"ingest_model_metadata"`
+	Status              types.String `tfsdk:"status"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	UpdatedAt           types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *DbtIntegrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbt_integration"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DbtIntegrationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *DbtIntegrationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "dbt Cloud integration resource. Ingests the lineage, model metadata and test results of dbt Cloud jobs running against a connection. " +
+			"The API token is never returned by the API, so it is not refreshed and is set on the next apply after an import.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Integration identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier the dbt jobs run against",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.Int64Attribute{
+				MarkdownDescription: "dbt Cloud account ID",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "dbt Cloud project ID",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"job_ids": schema.SetAttribute{
+				MarkdownDescription: "dbt Cloud job IDs whose runs are ingested. Every job of the project when unset",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
+			},
+			"api_token": schema.StringAttribute{
+				MarkdownDescription: "dbt Cloud service token with read access to the project",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"ingest_tests": schema.BoolAttribute{
+				MarkdownDescription: "Whether dbt test results are ingested. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"ingest_model_metadata": schema.BoolAttribute{
+				MarkdownDescription: "Whether dbt model metadata, such as descriptions and owners, is ingested. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Integration status",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// integration builds the API dbt integration from the model.
+func (m DbtIntegrationResourceModel) integration(ctx context.Context) (api.DbtIntegration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	jobIds := []int64{}
+	if !m.JobIds.IsNull() && !m.JobIds.IsUnknown() {
+		diags = m.JobIds.ElementsAs(ctx, &jobIds, false)
+	}
+
+	return api.DbtIntegration{
+		ID:                  m.Id.ValueString(),
+		ConnectionId:        m.ConnectionId.ValueString(),
+		AccountId:           m.AccountId.ValueInt64(),
+		ProjectId:           m.ProjectId.ValueInt64(),
+		JobIds:              jobIds,
+		ApiToken:            m.ApiToken.ValueString(),
+		IngestTests:         m.IngestTests.ValueBool(),
+		IngestModelMetadata: m.IngestModelMetadata.ValueBool(),
+	}, diags
+}
+
+// refresh maps the dbt integration returned by the API onto the model. The
+// API token is kept from the model since the API does not return it.
+func (m *DbtIntegrationResourceModel) refresh(ctx context.Context, integration *api.DbtIntegration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.StringValue(integration.ID)
+	m.ConnectionId = types.StringValue(integration.ConnectionId)
+	m.AccountId = types.Int64Value(integration.AccountId)
+	m.ProjectId = types.Int64Value(integration.ProjectId)
+	m.IngestTests = types.BoolValue(integration.IngestTests)
+	m.IngestModelMetadata = types.BoolValue(integration.IngestModelMetadata)
+	m.Status = types.StringValue(integration.Status)
+	m.CreatedAt = types.StringValue(integration.CreatedAt)
+	m.UpdatedAt = types.StringValue(integration.UpdatedAt)
+
+	// An empty list stays as configured, null or empty
+	if len(integration.JobIds) == 0 {
+		if m.JobIds.IsUnknown() || (!m.JobIds.IsNull() && len(m.JobIds.Elements()) > 0) {
+			m.JobIds = types.SetNull(types.Int64Type)
+		}
+		return diags
+	}
+	m.JobIds, diags = types.SetValueFrom(ctx, types.Int64Type, integration.JobIds)
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *DbtIntegrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan DbtIntegrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	integration, diags := plan.integration(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new dbt integration
+	created, err := r.client.CreateDbtIntegration(integration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating dbt integration",
+			"Could not create dbt integration, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DbtIntegrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DbtIntegrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed dbt integration value from API
+	integration, err := r.client.GetDbtIntegration(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane dbt Integration",
+			"Could not read Metaplane dbt Integration ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, integration)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DbtIntegrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan DbtIntegrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	integration, diags := plan.integration(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing dbt integration
+	updated, err := r.client.UpdateDbtIntegration(integration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating dbt integration",
+			"Could not update dbt integration, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DbtIntegrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DbtIntegrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDbtIntegration(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane dbt Integration",
+			"Could not delete dbt integration, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *DbtIntegrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,446 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &MaintenanceWindowResource{}
+	_ resource.ResourceWithConfigure        = &MaintenanceWindowResource{}
+	_ resource.ResourceWithImportState      = &MaintenanceWindowResource{}
+	_ resource.ResourceWithConfigValidators = &MaintenanceWindowResource{}
+	_ resource.ResourceWithValidateConfig   = &MaintenanceWindowResource{}
+)
+
+// NewMaintenanceWindowResource is a helper function to simplify the provider implementation.
+func NewMaintenanceWindowResource() resource.Resource {
+	return &MaintenanceWindowResource{}
+}
+
+// MaintenanceWindowResource is the resource implementation.
+type MaintenanceWindowResource struct {
+	client *api.Client
+}
+
+type MaintenanceWindowResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	StartTime    types.String `tfsdk:"start_time"`
+	EndTime      types.String `tfsdk:"end_time"`
+	CronTab      types.String `tfsdk:"cron_tab"`
+	Duration     types.String `tfsdk:"duration"`
+	Timezone     types.String `tfsdk:"timezone"`
+	ConnectionId types.String `tfsdk:"connection_id"`
+	PathGlobs    types.Set    `tfsdk:"path_globs"`
+	MonitorIds   types.Set    `tfsdk:"monitor_ids"`
+	Action       types.String `tfsdk:"action"`
+	Active       types.Bool   `tfsdk:"active"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *MaintenanceWindowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *MaintenanceWindowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *MaintenanceWindowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Maintenance window resource. Mutes the alerts of, or skips the runs of, the matched monitors during planned work such as migrations and backfills. " +
+			"A window is either one-off, set with `start_time` and `end_time`, or recurring, set with `cron_tab` and `duration`. " +
+			"Destroying the resource ends the window, including an occurrence in progress.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Maintenance window identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Maintenance window name",
+				Required:            true,
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Start of a one-off window, RFC 3339 timestamp",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "End of a one-off window, RFC 3339 timestamp",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"cron_tab": schema.StringAttribute{
+				MarkdownDescription: "Cron expression of the starts of a recurring window, evaluated in `timezone`",
+				Optional:            true,
+			},
+			"duration": schema.StringAttribute{
+				MarkdownDescription: "Duration of each occurrence of a recurring window in whole minutes, e.g. `90m` or `4h`",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone of `cron_tab`. Defaults to `UTC`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UTC"),
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Match monitors of this connection",
+				Optional:            true,
+			},
+			"path_globs": schema.SetAttribute{
+				MarkdownDescription: "Match monitors whose absolute path matches one of these globs, e.g. `ANALYTICS.FINANCE.*`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"monitor_ids": schema.SetAttribute{
+				MarkdownDescription: "Match these monitors",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "What happens to the matched monitors during the window: `MUTE_ALERTS` keeps running them without alerting, `SKIP_RUNS` does not run them. Defaults to `MUTE_ALERTS`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(api.MaintenanceActionMuteAlerts),
+				Validators: []validator.String{
+					stringvalidator.OneOf(api.MaintenanceActionMuteAlerts, api.MaintenanceActionSkipRuns),
+				},
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the window is currently in effect",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires either a one-off or a recurring schedule, and a
+// scope.
+func (r *MaintenanceWindowResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("start_time"),
+			path.MatchRoot("cron_tab"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("start_time"),
+			path.MatchRoot("end_time"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("cron_tab"),
+			path.MatchRoot("duration"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("connection_id"),
+			path.MatchRoot("path_globs"),
+			path.MatchRoot("monitor_ids"),
+		),
+	}
+}
+
+// ValidateConfig checks that a one-off window ends after it starts and that
+// a recurring window lasts whole minutes.
+func (r *MaintenanceWindowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config MaintenanceWindowResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.StartTime.IsNull() && !config.StartTime.IsUnknown() && !config.EndTime.IsNull() && !config.EndTime.IsUnknown() {
+		start, startErr := time.Parse(time.RFC3339, config.StartTime.ValueString())
+		end, endErr := time.Parse(time.RFC3339, config.EndTime.ValueString())
+		if startErr == nil && endErr == nil && !end.After(start) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("end_time"),
+				"Invalid Maintenance Window",
+				fmt.Sprintf("end_time %s must be after start_time %s.", config.EndTime.ValueString(), config.StartTime.ValueString()),
+			)
+		}
+	}
+
+	if !config.Duration.IsNull() && !config.Duration.IsUnknown() {
+		duration, err := time.ParseDuration(config.Duration.ValueString())
+		if err == nil && duration%time.Minute != 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("duration"),
+				"Invalid Maintenance Window",
+				fmt.Sprintf("duration must be a whole number of minutes, got: %q.", config.Duration.ValueString()),
+			)
+		}
+	}
+}
+
+// window builds the API maintenance window from the model.
+func (m MaintenanceWindowResourceModel) window(ctx context.Context) (api.MaintenanceWindow, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	pathGlobs, d := stringSetElements(ctx, m.PathGlobs)
+	diags.Append(d...)
+	monitorIds, d := stringSetElements(ctx, m.MonitorIds)
+	diags.Append(d...)
+
+	window := api.MaintenanceWindow{
+		ID:       m.Id.ValueString(),
+		Name:     m.Name.ValueString(),
+		StartsAt: m.StartTime.ValueStringPointer(),
+		EndsAt:   m.EndTime.ValueStringPointer(),
+		CronTab:  m.CronTab.ValueStringPointer(),
+		Timezone: m.Timezone.ValueString(),
+		Scope: api.MaintenanceWindowScope{
+			ConnectionId: m.ConnectionId.ValueStringPointer(),
+			PathGlobs:    pathGlobs,
+			MonitorIds:   monitorIds,
+		},
+		Action: m.Action.ValueString(),
+	}
+	if !m.Duration.IsNull() {
+		duration, err := time.ParseDuration(m.Duration.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("duration"), "Invalid Duration", err.Error())
+			return window, diags
+		}
+		minutes := int64(duration / time.Minute)
+		window.DurationMinutes = &minutes
+	}
+
+	return window, diags
+}
+
+// refresh maps the maintenance window returned by the API onto the model.
+func (m *MaintenanceWindowResourceModel) refresh(ctx context.Context, window *api.MaintenanceWindow) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.StringValue(window.ID)
+	m.Name = types.StringValue(window.Name)
+	m.StartTime = timestampValue(window.StartsAt, m.StartTime)
+	m.EndTime = timestampValue(window.EndsAt, m.EndTime)
+	m.CronTab = optionalStringValue(window.CronTab, m.CronTab)
+	m.Duration = durationMinutesValue(window.DurationMinutes, m.Duration)
+	m.Timezone = types.StringValue(window.Timezone)
+	m.ConnectionId = optionalStringValue(window.Scope.ConnectionId, m.ConnectionId)
+	m.Action = types.StringValue(window.Action)
+	m.Active = types.BoolValue(window.IsActive)
+	m.CreatedAt = types.StringValue(window.CreatedAt)
+	m.UpdatedAt = types.StringValue(window.UpdatedAt)
+
+	pathGlobs, d := stringSetValue(ctx, window.Scope.PathGlobs, m.PathGlobs)
+	diags.Append(d...)
+	m.PathGlobs = pathGlobs
+
+	monitorIds, d := stringSetValue(ctx, window.Scope.MonitorIds, m.MonitorIds)
+	diags.Append(d...)
+	m.MonitorIds = monitorIds
+
+	return diags
+}
+
+// durationMinutesValue maps a number of minutes returned by the API to a
+// duration. The prior value is kept when it denotes the same duration.
+func durationMinutesValue(minutes *int64, prior types.String) types.String {
+	if minutes == nil {
+		return types.StringNull()
+	}
+	duration := time.Duration(*minutes) * time.Minute
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorDuration, err := time.ParseDuration(prior.ValueString())
+		if err == nil && priorDuration == duration {
+			return prior
+		}
+	}
+	return types.StringValue(fmt.Sprintf("%dm", *minutes))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *MaintenanceWindowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan MaintenanceWindowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	window, diags := plan.window(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new maintenance window
+	created, err := r.client.CreateMaintenanceWindow(window)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating maintenance window",
+			"Could not create maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *MaintenanceWindowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state MaintenanceWindowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed maintenance window value from API
+	window, err := r.client.GetMaintenanceWindow(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Maintenance Window",
+			"Could not read Metaplane Maintenance Window ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, window)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *MaintenanceWindowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan MaintenanceWindowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	window, diags := plan.window(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing maintenance window
+	updated, err := r.client.UpdateMaintenanceWindow(window)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating maintenance window",
+			"Could not update maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete ends the maintenance window and removes the Terraform state on success.
+func (r *MaintenanceWindowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state MaintenanceWindowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteMaintenanceWindow(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Maintenance Window",
+			"Could not delete maintenance window, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *MaintenanceWindowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
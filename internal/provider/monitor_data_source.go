@@ -3,15 +3,22 @@ package provider
 import (
   "context"
   "fmt"
+  "strings"
   
   "github.com/klaviyo/terraform-provider-metaplane/internal/api"
-  "github.com/hashicorp/terraform-plugin-framework/types"
+  "github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+  "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
   "github.com/hashicorp/terraform-plugin-framework/datasource"
   "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+  "github.com/hashicorp/terraform-plugin-framework/path"
+  "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &MonitorDataSource{}
+var (
+  _ datasource.DataSource                     = &MonitorDataSource{}
+  _ datasource.DataSourceWithConfigValidators = &MonitorDataSource{}
+)
 
 func NewMonitorDataSource() datasource.DataSource {
 	return &MonitorDataSource{}
@@ -22,93 +29,59 @@ type MonitorDataSource struct {
 	client *api.Client
 }
 
-type MonitorDataSourceModel struct {
-	ConnectionId          types.String          `tfsdk:"connection_id"`
-	MonitorId             types.String          `tfsdk:"monitor_id"`
-  Type                  types.String          `tfsdk:"type"`
-  CronTab               types.String          `tfsdk:"cron_tab"`
-  IsEnabled             types.Bool            `tfsdk:"is_enabled"`
-  AbsolutePath          types.String          `tfsdk:"absolute_path"`
-  UpdatedAt             types.String          `tfsdk:"updated_at"`
-  CreatedAt             types.String          `tfsdk:"created_at"`
-  CustomSql             types.String          `tfsdk:"custom_sql"`
-  CustomWhereClause     types.String          `tfsdk:"custom_where_clause"`
-  IncrementalColumnName types.String          `tfsdk:"incremental_column_name"`
-  IncrementalDays       types.Int64           `tfsdk:"incremental_days"`
-  IncrementalHours      types.Int64           `tfsdk:"incremental_hours"`
-  IncrementalMinutes    types.Int64           `tfsdk:"incremental_minutes"`
-}
-
 func (d *MonitorDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_monitor"
 }
 
 func (d *MonitorDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+  // The lookup attributes are optional, everything else is computed
+  attributes := monitorDataSourceAttributes()
+  attributes["monitor_id"] = schema.StringAttribute{
+    MarkdownDescription: "Monitor identifier",
+    Optional:            true,
+    Computed:            true,
+  }
+  attributes["connection_id"] = schema.StringAttribute{
+    MarkdownDescription: "Connection identifier. When set with `monitor_id`, the monitor must belong to this connection",
+    Optional:            true,
+    Computed:            true,
+  }
+  attributes["type"] = schema.StringAttribute{
+    MarkdownDescription: "Type of monitor, row_count, etc",
+    Optional:            true,
+    Computed:            true,
+  }
+  attributes["absolute_path"] = schema.StringAttribute{
+    MarkdownDescription: "{database}.{schema}.{table}.{column}",
+    Optional:            true,
+    Computed:            true,
+    Validators: []validator.String{
+      stringvalidator.AlsoRequires(path.MatchRoot("connection_id")),
+    },
+  }
+
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Monitor data source",
-		Attributes: map[string]schema.Attribute{
-			"monitor_id": schema.StringAttribute{
-				MarkdownDescription: "Monitor identifier",
-				Required:            true,
-			},
-			"connection_id": schema.StringAttribute{
-				MarkdownDescription: "Connection identifier",
-				Required:            true,
-			},
-			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of monitor, row_count, etc",
-				Computed:            true,
-			},
-			"cron_tab": schema.StringAttribute{
-				MarkdownDescription: "cron job schedule in * * * * * format",
-				Computed:            true,
-			},
-			"is_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Example identifier",
-				Computed:            true,
-			},
-			"absolute_path": schema.StringAttribute{
-				MarkdownDescription: "{database}.{schema}.{table}.{column}",
-				Computed:            true,
-			},
-			"updated_at": schema.StringAttribute{
-				MarkdownDescription: "datetime updated",
-				Computed:            true,
-			},
-			"created_at": schema.StringAttribute{
-				MarkdownDescription: "datetime created",
-				Computed:            true,
-			},
-			"custom_sql": schema.StringAttribute{
-				MarkdownDescription: "custom sql",
-				Required: false,
-        Optional: true,
-			},
-			"custom_where_clause": schema.StringAttribute{
-				MarkdownDescription: "custom where clause",
-				Required: false,
-        Optional: true,
-			},
-      "incremental_column_name": schema.StringAttribute{
-        MarkdownDescription: "Incremental column name",
-        Optional: true,
-      },
-      "incremental_days": schema.Int64Attribute{
-        MarkdownDescription: "Incremental days",
-        Optional: true,
-      },
-      "incremental_hours": schema.Int64Attribute{
-        MarkdownDescription: "Incremental hours",
-        Optional: true,
-      },
-      "incremental_minutes": schema.Int64Attribute{
-        MarkdownDescription: "Incremental minutes",
-        Optional: true,
-      },
-		},
+		MarkdownDescription: "Monitor data source. Looks up a monitor either by `monitor_id`, or by `connection_id`, `absolute_path` and `type`, " +
+      "the latter compared case-insensitively. Fails when no monitor or more than one monitor matches.",
+		Attributes: attributes,
 	}
 }
 
+// ConfigValidators requires either a monitor ID, or an absolute path and a
+// type.
+func (d *MonitorDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+  return []datasource.ConfigValidator{
+    datasourcevalidator.ExactlyOneOf(
+      path.MatchRoot("monitor_id"),
+      path.MatchRoot("absolute_path"),
+    ),
+    datasourcevalidator.RequiredTogether(
+      path.MatchRoot("absolute_path"),
+      path.MatchRoot("type"),
+    ),
+  }
+}
+
 func (d *MonitorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -130,7 +103,7 @@ func (d *MonitorDataSource) Configure(ctx context.Context, req datasource.Config
 }
 
 func (d *MonitorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state MonitorDataSourceModel
+	var state MonitorModel
 
   // Parse the state from the request
   if err := req.Config.Get(ctx, &state); err != nil {
@@ -138,34 +111,59 @@ func (d *MonitorDataSource) Read(ctx context.Context, req datasource.ReadRequest
     return
   }
 
-  monitorId := state.MonitorId.ValueString()
-
-  monitor, err := d.client.GetMonitor(monitorId)
-
+  monitor, err := d.findMonitor(state)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read monitor, got error: %s", err))
 		return
 	}
 
-  state.Type                  = types.StringValue(monitor.Type)
-  state.CronTab               = types.StringValue(monitor.CronTab)
-  state.IsEnabled             = types.BoolValue  (monitor.IsEnabled)
-  state.AbsolutePath          = types.StringValue(monitor.AbsolutePath)
-  state.UpdatedAt             = types.StringValue(monitor.UpdatedAt)
-  state.CreatedAt             = types.StringValue(monitor.CreatedAt)
-  state.CustomWhereClause     = types.StringValue(*monitor.Config.CustomWhereClause)
-  state.IncrementalColumnName = types.StringValue(*monitor.Config.IncrementalClause.ColumnName)
-  state.IncrementalDays       = types.Int64Value(*monitor.Config.IncrementalClause.Duration.Days)
-  state.IncrementalHours      = types.Int64Value(*monitor.Config.IncrementalClause.Duration.Hours)
-  state.IncrementalMinutes    = types.Int64Value(*monitor.Config.IncrementalClause.Duration.Minutes)
+  diags := state.refresh(ctx, monitor)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+    return
+  }
 
   // Set state
-  diags := resp.State.Set(ctx, &state)
+  diags = resp.State.Set(ctx, &state)
   resp.Diagnostics.Append(diags...)
   if resp.Diagnostics.HasError() {
     return;
   }
 }
 
+// findMonitor returns the monitor with the configured ID, or else the only
+// monitor of the connection with the configured absolute path and type.
+func (d *MonitorDataSource) findMonitor(config MonitorModel) (*api.Monitor, error) {
+  connectionId := config.ConnectionId.ValueString()
+
+  if !config.MonitorId.IsNull() {
+    monitor, err := d.client.GetMonitor(config.MonitorId.ValueString())
+    if err != nil {
+      return nil, err
+    }
+    if connectionId != "" && monitor.ConnectionId != connectionId {
+      return nil, fmt.Errorf("monitor %s belongs to connection %s, not %s", monitor.ID, monitor.ConnectionId, connectionId)
+    }
+    return monitor, nil
+  }
 
+  absolutePath := config.AbsolutePath.ValueString()
+  monitorType := config.Type.ValueString()
+  monitors, err := d.client.FindMonitors(connectionId, absolutePath, monitorType)
+  if err != nil {
+    return nil, err
+  }
+
+  switch len(monitors) {
+  case 0:
+    return nil, fmt.Errorf("no %s monitor on %s in connection %s", monitorType, absolutePath, connectionId)
+  case 1:
+    return &monitors[0], nil
+  }
 
+  ids := make([]string, 0, len(monitors))
+  for _, monitor := range monitors {
+    ids = append(ids, monitor.ID)
+  }
+  return nil, fmt.Errorf("%d %s monitors on %s in connection %s, set monitor_id to one of: %s", len(monitors), monitorType, absolutePath, connectionId, strings.Join(ids, ", "))
+}
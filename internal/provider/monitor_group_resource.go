@@ -0,0 +1,514 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gopath "path"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &MonitorGroupResource{}
+	_ resource.ResourceWithConfigure      = &MonitorGroupResource{}
+	_ resource.ResourceWithModifyPlan     = &MonitorGroupResource{}
+	_ resource.ResourceWithValidateConfig = &MonitorGroupResource{}
+)
+
+// NewMonitorGroupResource is a helper function to simplify the provider implementation.
+func NewMonitorGroupResource() resource.Resource {
+	return &MonitorGroupResource{}
+}
+
+// MonitorGroupResource is the resource implementation.
+type MonitorGroupResource struct {
+	client *api.Client
+}
+
+type MonitorGroupResourceModel struct {
+	Id                 types.String          `tfsdk:"id"`
+	ConnectionId       types.String          `tfsdk:"connection_id"`
+	Name               types.String          `tfsdk:"name"`
+	Include            types.Set             `tfsdk:"include"`
+	Exclude            types.Set             `tfsdk:"exclude"`
+	Template           *MonitorTemplateModel `tfsdk:"template"`
+	MatchedPaths       types.Set             `tfsdk:"matched_paths"`
+	MonitorIds         types.Map             `tfsdk:"monitor_ids"`
+	DisabledMonitorIds types.Map             `tfsdk:"disabled_monitor_ids"`
+}
+
+type MonitorTemplateModel struct {
+	Type                  types.String `tfsdk:"type"`
+	CronTab               types.String `tfsdk:"cron_tab"`
+	CustomWhereClause     types.String `tfsdk:"custom_where_clause"`
+	IncrementalColumnName types.String `tfsdk:"incremental_column_name"`
+	IncrementalDays       types.Int64  `tfsdk:"incremental_days"`
+	IncrementalHours      types.Int64  `tfsdk:"incremental_hours"`
+	IncrementalMinutes    types.Int64  `tfsdk:"incremental_minutes"`
+}
+
+// Metadata returns the resource type name.
+func (r *MonitorGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_group"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *MonitorGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *MonitorGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Monitor group resource. Creates one monitor from a template for every table of the connection catalog matching the `include` globs and none of the `exclude` globs. " +
+			"The globs are expanded on every plan, so that new tables are monitored and the monitors of tables that no longer match are disabled on the next apply, and enabled again when the tables match again. " +
+			"The group does not take over monitors managed elsewhere, creating a monitor that already exists fails. " +
+			"Globs are case-insensitive and `*` also matches dots, e.g. `ANALYTICS.MARTS.*` matches every table of the `MARTS` schema. " +
+			"If the catalog changes between plan and apply, plan again. The group cannot be imported.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the group, `{connection_id}:{name}`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the group, unique per connection",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include": schema.SetAttribute{
+				MarkdownDescription: "Globs of the absolute paths of the tables to monitor, e.g. `ANALYTICS.MARTS.*`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"exclude": schema.SetAttribute{
+				MarkdownDescription: "Globs of the absolute paths of the tables not to monitor, e.g. `ANALYTICS.MARTS.TMP_*`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"template": schema.SingleNestedAttribute{
+				MarkdownDescription: "Monitor created for every matched table",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Type of monitor: " + strings.Join(tableMonitorKinds, ", "),
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(tableMonitorKinds...),
+						},
+					},
+					"cron_tab": schema.StringAttribute{
+						MarkdownDescription: "cron job schedule in * * * * * format",
+						Required:            true,
+					},
+					"custom_where_clause": schema.StringAttribute{
+						MarkdownDescription: "custom where clause",
+						Optional:            true,
+					},
+					"incremental_column_name": schema.StringAttribute{
+						MarkdownDescription: "Incremental column name",
+						Optional:            true,
+					},
+					"incremental_days": schema.Int64Attribute{
+						MarkdownDescription: "Incremental days",
+						Optional:            true,
+					},
+					"incremental_hours": schema.Int64Attribute{
+						MarkdownDescription: "Incremental hours",
+						Optional:            true,
+					},
+					"incremental_minutes": schema.Int64Attribute{
+						MarkdownDescription: "Incremental minutes",
+						Optional:            true,
+					},
+				},
+			},
+			"matched_paths": schema.SetAttribute{
+				MarkdownDescription: "Absolute paths of the tables matched by the globs",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors of the group keyed by absolute path",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"disabled_monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors the group disabled, keyed by absolute path. They are enabled again when desired again",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the syntax of the globs.
+func (r *MonitorGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config MonitorGroupResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sets := map[string]types.Set{
+		"include": config.Include,
+		"exclude": config.Exclude,
+	}
+	for _, name := range sortedKeys(sets) {
+		if sets[name].IsNull() || sets[name].IsUnknown() {
+			continue
+		}
+
+		globs := []types.String{}
+		resp.Diagnostics.Append(sets[name].ElementsAs(ctx, &globs, false)...)
+		for _, glob := range globs {
+			if glob.IsUnknown() {
+				continue
+			}
+			if err := validatePattern(api.PatternSyntaxGlob, glob.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid Glob",
+					fmt.Sprintf("%q is not a valid glob: %s.", glob.ValueString(), err),
+				)
+			}
+		}
+	}
+}
+
+// ModifyPlan expands the globs against the table catalog of the connection.
+// The monitors are planned for an update when the matched tables, the
+// template or the monitors found on refresh changed.
+func (r *MonitorGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan MonitorGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ConnectionId.IsUnknown() || plan.Include.IsUnknown() || plan.Exclude.IsUnknown() {
+		return
+	}
+
+	matched, diags := r.expand(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchedPaths, diags := types.SetValueFrom(ctx, types.StringType, matched)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("matched_paths"), matchedPaths)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state MonitorGroupResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statePaths, diags := stringSetElements(ctx, state.MatchedPaths)
+	resp.Diagnostics.Append(diags...)
+	ids, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the monitors when neither the specs nor the monitors changed
+	unchanged := reflect.DeepEqual(state.specs(statePaths), plan.specs(matched)) && reflect.DeepEqual(sortedKeys(ids.Enabled), matched)
+	if unchanged {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monitor_ids"), state.MonitorIds)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("disabled_monitor_ids"), state.DisabledMonitorIds)...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monitor_ids"), types.MapUnknown(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("disabled_monitor_ids"), types.MapUnknown(types.StringType))...)
+}
+
+// expand returns the sorted absolute paths of the tables of the catalog
+// matching the globs of the group.
+func (r *MonitorGroupResource) expand(ctx context.Context, m MonitorGroupResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	include, d := stringSetElements(ctx, m.Include)
+	diags.Append(d...)
+	exclude, d := stringSetElements(ctx, m.Exclude)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tables, err := r.client.ListTables(m.ConnectionId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Listing Metaplane Tables",
+			"Could not list the tables of connection "+m.ConnectionId.ValueString()+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	matched := map[string]struct{}{}
+	for _, table := range tables {
+		if matchesAnyGlob(include, table.AbsolutePath) && !matchesAnyGlob(exclude, table.AbsolutePath) {
+			matched[table.AbsolutePath] = struct{}{}
+		}
+	}
+	return sortedKeys(matched), diags
+}
+
+// matchedPaths returns the matched paths of the plan. They are unknown in the
+// plan when the connection or the globs were, e.g. for a connection created
+// in the same apply, and are then expanded now and stored in the plan.
+func (r *MonitorGroupResource) matchedPaths(ctx context.Context, plan *MonitorGroupResourceModel) ([]string, diag.Diagnostics) {
+	if !plan.MatchedPaths.IsUnknown() {
+		return stringSetElements(ctx, plan.MatchedPaths)
+	}
+
+	matched, diags := r.expand(ctx, *plan)
+	if diags.HasError() {
+		return nil, diags
+	}
+	var d diag.Diagnostics
+	plan.MatchedPaths, d = types.SetValueFrom(ctx, types.StringType, matched)
+	diags.Append(d...)
+	return matched, diags
+}
+
+// monitorGroupError returns the detail of an error reconciling the monitors
+// of a group.
+func monitorGroupError(action string, err error) string {
+	detail := "Could not " + action + " the monitors of the group, unexpected error: " + err.Error()
+	if errors.Is(err, api.ErrMonitorExists) {
+		detail = "Could not " + action + " the monitors of the group: " + err.Error() + ". " +
+			"A monitor group does not take over monitors managed elsewhere, e.g. by metaplane_monitor or metaplane_table_monitors. " +
+			"Exclude the table from the group or remove the other monitor."
+	}
+	return detail
+}
+
+// matchesAnyGlob reports whether an absolute path matches one of the globs,
+// case-insensitive.
+func matchesAnyGlob(globs []string, absolutePath string) bool {
+	for _, glob := range globs {
+		if ok, _ := gopath.Match(strings.ToUpper(glob), strings.ToUpper(absolutePath)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// specs returns the desired monitors of the group keyed by absolute path.
+func (m MonitorGroupResourceModel) specs(paths []string) map[string]monitorSpec {
+	specs := map[string]monitorSpec{}
+	if m.Template == nil {
+		return specs
+	}
+
+	template := m.Template
+	for _, absolutePath := range paths {
+		spec := monitorSpec{
+			EntityType:   "TABLE",
+			Type:         template.Type.ValueString(),
+			AbsolutePath: absolutePath,
+			CronTab:      template.CronTab.ValueString(),
+		}
+		if template.Type.ValueString() != "SCHEMA_CHANGE" {
+			spec.Config.CustomWhereClause = template.CustomWhereClause.ValueStringPointer()
+			spec.Config.IncrementalClause = incrementalClause(template.IncrementalColumnName, template.IncrementalDays, template.IncrementalHours, template.IncrementalMinutes)
+		}
+		specs[absolutePath] = spec
+	}
+	return specs
+}
+
+// monitorIds returns the IDs of the monitors of the group keyed by absolute path.
+func (m MonitorGroupResourceModel) monitorIds(ctx context.Context) (monitorSetIds, diag.Diagnostics) {
+	return newMonitorSetIds(ctx, m.MonitorIds, m.DisabledMonitorIds)
+}
+
+// setMonitorIds stores the IDs of the monitors of the group.
+func (m *MonitorGroupResourceModel) setMonitorIds(ctx context.Context, ids monitorSetIds) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(m.ConnectionId.ValueString() + ":" + m.Name.ValueString())
+	m.MonitorIds, m.DisabledMonitorIds, diags = ids.values(ctx)
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *MonitorGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan MonitorGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched, diags := r.matchedPaths(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the monitors, saving the ones created so far on error
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), nil, plan.specs(matched), monitorSetIds{}, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating monitor group",
+			monitorGroupError("create", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. Monitors that were
+// disabled outside of Terraform move to the disabled ones and deleted ones
+// are dropped, so that the next plan brings them back. The globs are expanded by the plan, not here.
+func (r *MonitorGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state MonitorGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, ids, err := readMonitors(r.client, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Monitor Group",
+			"Could not read monitors of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setMonitorIds(ctx, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *MonitorGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state MonitorGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statePaths, diags := stringSetElements(ctx, state.MatchedPaths)
+	resp.Diagnostics.Append(diags...)
+	matched, diags := r.matchedPaths(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), state.specs(statePaths), plan.specs(matched), current, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating monitor group",
+			monitorGroupError("update", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables the monitors of the group and removes the Terraform state on success.
+func (r *MonitorGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state MonitorGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := reconcileMonitors(ctx, r.client, state.ConnectionId.ValueString(), nil, nil, current, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Monitor Group",
+			"Could not disable the monitors of the group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// MonitorModel holds the attributes of a monitor reported by the API. It is
+// the model of the monitor data sources, and the monitor resource maps its
+// own model through it, so that every one of them reads monitors the same way.
+type MonitorModel struct {
+	MonitorId             types.String  `tfsdk:"monitor_id"`
+	ConnectionId          types.String  `tfsdk:"connection_id"`
+	Type                  types.String  `tfsdk:"type"`
+	EntityType            types.String  `tfsdk:"entity_type"`
+	AbsolutePath          types.String  `tfsdk:"absolute_path"`
+	CronTab               types.String  `tfsdk:"cron_tab"`
+	IsEnabled             types.Bool    `tfsdk:"is_enabled"`
+	CreatedAt             types.String  `tfsdk:"created_at"`
+	UpdatedAt             types.String  `tfsdk:"updated_at"`
+	CustomSql             types.String  `tfsdk:"custom_sql"`
+	CustomWhereClause     types.String  `tfsdk:"custom_where_clause"`
+	IncrementalColumnName types.String  `tfsdk:"incremental_column_name"`
+	IncrementalDays       types.Int64   `tfsdk:"incremental_days"`
+	IncrementalHours      types.Int64   `tfsdk:"incremental_hours"`
+	IncrementalMinutes    types.Int64   `tfsdk:"incremental_minutes"`
+	ThresholdType         types.String  `tfsdk:"threshold_type"`
+	LowerBound            types.Float64 `tfsdk:"lower_bound"`
+	UpperBound            types.Float64 `tfsdk:"upper_bound"`
+	Sensitivity           types.String  `tfsdk:"sensitivity"`
+	TrainingWindowDays    types.Int64   `tfsdk:"training_window_days"`
+	LookbackWindowDays    types.Int64   `tfsdk:"lookback_window_days"`
+	Name                  types.String  `tfsdk:"name"`
+	Description           types.String  `tfsdk:"description"`
+	Owners                types.Set     `tfsdk:"owners"`
+	Priority              types.String  `tfsdk:"priority"`
+	Tags                  types.Set     `tfsdk:"tags"`
+}
+
+// monitorDataSourceAttributes returns the computed data source attributes of
+// a monitor, matching MonitorModel.
+func monitorDataSourceAttributes() map[string]schema.Attribute {
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+	computedInt64 := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{MarkdownDescription: description, Computed: true}
+	}
+	computedFloat64 := func(description string) schema.Float64Attribute {
+		return schema.Float64Attribute{MarkdownDescription: description, Computed: true}
+	}
+	computedStringSet := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{MarkdownDescription: description, ElementType: types.StringType, Computed: true}
+	}
+
+	return map[string]schema.Attribute{
+		"monitor_id":              computedString("Monitor identifier"),
+		"connection_id":           computedString("Connection identifier"),
+		"type":                    computedString("Type of monitor, row_count, etc"),
+		"entity_type":             computedString("Entity type: `TABLE` or `COLUMN`"),
+		"absolute_path":           computedString("{database}.{schema}.{table}.{column}"),
+		"cron_tab":                computedString("cron job schedule in * * * * * format"),
+		"is_enabled":              schema.BoolAttribute{MarkdownDescription: "Whether the monitor is enabled", Computed: true},
+		"created_at":              computedString("datetime created"),
+		"updated_at":              computedString("datetime updated"),
+		"custom_sql":              computedString("custom sql"),
+		"custom_where_clause":     computedString("custom where clause"),
+		"incremental_column_name": computedString("Incremental column name"),
+		"incremental_days":        computedInt64("Incremental days"),
+		"incremental_hours":       computedInt64("Incremental hours"),
+		"incremental_minutes":     computedInt64("Incremental minutes"),
+		"threshold_type":          computedString("Threshold type: `STATIC` or `ML`"),
+		"lower_bound":             computedFloat64("Lower bound of a static threshold"),
+		"upper_bound":             computedFloat64("Upper bound of a static threshold"),
+		"sensitivity":             computedString("Sensitivity of the anomaly model"),
+		"training_window_days":    computedInt64("Days of history the anomaly model trains on"),
+		"lookback_window_days":    computedInt64("Days of recent results compared against the model"),
+		"name":                    computedString("Human readable name, shown in alerts"),
+		"description":             computedString("Description of what the monitor checks, shown in alerts"),
+		"owners":                  computedStringSet("Emails of the owners of the monitor"),
+		"priority":                computedString("Priority of the monitor"),
+		"tags":                    computedStringSet("Tags of the monitor"),
+	}
+}
+
+// monitorFields points to the attributes of a model that map a monitor
+// returned by the API. Every model reading monitors lists its attributes in
+// a single fields method, and attributes a model does not have stay nil and
+// are skipped, so that the mapping itself lives in one place.
+type monitorFields struct {
+	MonitorId             *types.String
+	ConnectionId          *types.String
+	Type                  *types.String
+	EntityType            *types.String
+	AbsolutePath          *types.String
+	CronTab               *types.String
+	IsEnabled             *types.Bool
+	CreatedAt             *types.String
+	UpdatedAt             *types.String
+	CustomSql             *types.String
+	CustomWhereClause     *types.String
+	IncrementalColumnName *types.String
+	IncrementalDays       *types.Int64
+	IncrementalHours      *types.Int64
+	IncrementalMinutes    *types.Int64
+	ThresholdType         *types.String
+	LowerBound            *types.Float64
+	UpperBound            *types.Float64
+	Sensitivity           *types.String
+	TrainingWindowDays    *types.Int64
+	LookbackWindowDays    *types.Int64
+	Name                  *types.String
+	Description           *types.String
+	Owners                *types.Set
+	Priority              *types.String
+	Tags                  *types.Set
+}
+
+// fields returns the attributes of the model that map a monitor.
+func (m *MonitorModel) fields() monitorFields {
+	return monitorFields{
+		MonitorId:             &m.MonitorId,
+		ConnectionId:          &m.ConnectionId,
+		Type:                  &m.Type,
+		EntityType:            &m.EntityType,
+		AbsolutePath:          &m.AbsolutePath,
+		CronTab:               &m.CronTab,
+		IsEnabled:             &m.IsEnabled,
+		CreatedAt:             &m.CreatedAt,
+		UpdatedAt:             &m.UpdatedAt,
+		CustomSql:             &m.CustomSql,
+		CustomWhereClause:     &m.CustomWhereClause,
+		IncrementalColumnName: &m.IncrementalColumnName,
+		IncrementalDays:       &m.IncrementalDays,
+		IncrementalHours:      &m.IncrementalHours,
+		IncrementalMinutes:    &m.IncrementalMinutes,
+		ThresholdType:         &m.ThresholdType,
+		LowerBound:            &m.LowerBound,
+		UpperBound:            &m.UpperBound,
+		Sensitivity:           &m.Sensitivity,
+		TrainingWindowDays:    &m.TrainingWindowDays,
+		LookbackWindowDays:    &m.LookbackWindowDays,
+		Name:                  &m.Name,
+		Description:           &m.Description,
+		Owners:                &m.Owners,
+		Priority:              &m.Priority,
+		Tags:                  &m.Tags,
+	}
+}
+
+// refresh maps the monitor returned by the API onto the model.
+func (m *MonitorModel) refresh(ctx context.Context, monitor *api.Monitor) diag.Diagnostics {
+	return m.fields().refresh(ctx, monitor)
+}
+
+// refresh maps the monitor returned by the API onto the fields. The config,
+// its incremental clause, threshold and windows may all be missing, e.g. for
+// plain row count monitors. Optional attributes the API reports as empty
+// stay null when they are null in the model, and the type, entity type and
+// path keep the case of the model.
+func (f monitorFields) refresh(ctx context.Context, monitor *api.Monitor) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config := monitor.Config
+	if config == nil {
+		config = &api.Config{}
+	}
+	incrementalClause := config.IncrementalClause
+	if incrementalClause == nil {
+		incrementalClause = &api.IncrementalClause{}
+	}
+	duration := incrementalClause.Duration
+	if duration == nil {
+		duration = &api.Duration{}
+	}
+	threshold := config.Threshold
+	if threshold == nil {
+		threshold = &api.Threshold{}
+	}
+	trainingWindow := config.TrainingWindow
+	if trainingWindow == nil {
+		trainingWindow = &api.Duration{}
+	}
+	lookbackWindow := config.LookbackWindow
+	if lookbackWindow == nil {
+		lookbackWindow = &api.Duration{}
+	}
+
+	refreshField(f.CustomSql, func(prior types.String) types.String {
+		return optionalStringValue(config.CustomSql, prior)
+	})
+	refreshField(f.CustomWhereClause, func(prior types.String) types.String {
+		return optionalStringValue(config.CustomWhereClause, prior)
+	})
+	refreshField(f.IncrementalColumnName, func(prior types.String) types.String {
+		return optionalStringValue(incrementalClause.ColumnName, prior)
+	})
+	refreshField(f.IncrementalDays, func(prior types.Int64) types.Int64 {
+		return optionalInt64Value(duration.Days, prior)
+	})
+	refreshField(f.IncrementalHours, func(prior types.Int64) types.Int64 {
+		return optionalInt64Value(duration.Hours, prior)
+	})
+	refreshField(f.IncrementalMinutes, func(prior types.Int64) types.Int64 {
+		return optionalInt64Value(duration.Minutes, prior)
+	})
+
+	setField(f.ThresholdType, stringValueOrNull(threshold.Type))
+	setField(f.LowerBound, types.Float64PointerValue(threshold.LowerBound))
+	setField(f.UpperBound, types.Float64PointerValue(threshold.UpperBound))
+	refreshField(f.Sensitivity, func(prior types.String) types.String {
+		return optionalStringValue(config.Sensitivity, prior)
+	})
+	setField(f.TrainingWindowDays, types.Int64PointerValue(trainingWindow.Days))
+	setField(f.LookbackWindowDays, types.Int64PointerValue(lookbackWindow.Days))
+
+	setField(f.MonitorId, types.StringValue(monitor.ID))
+	setField(f.ConnectionId, types.StringValue(monitor.ConnectionId))
+	refreshField(f.Type, func(prior types.String) types.String {
+		return caseInsensitiveStringValue(monitor.Type, prior)
+	})
+	refreshField(f.EntityType, func(prior types.String) types.String {
+		return caseInsensitiveStringValue(monitor.EntityType, prior)
+	})
+	refreshField(f.AbsolutePath, func(prior types.String) types.String {
+		return caseInsensitiveStringValue(monitor.AbsolutePath, prior)
+	})
+	setField(f.CronTab, types.StringValue(monitor.CronTab))
+	setField(f.IsEnabled, types.BoolValue(monitor.IsEnabled))
+	setField(f.CreatedAt, types.StringValue(monitor.CreatedAt))
+	setField(f.UpdatedAt, types.StringValue(monitor.UpdatedAt))
+	setField(f.Name, stringValueOrNull(monitor.Name))
+	setField(f.Description, stringValueOrNull(monitor.Description))
+	setField(f.Priority, stringValueOrNull(monitor.Priority))
+
+	var d diag.Diagnostics
+	if f.Owners != nil {
+		*f.Owners, d = stringSetValue(ctx, monitor.Owners, *f.Owners)
+		diags.Append(d...)
+	}
+	if f.Tags != nil {
+		*f.Tags, d = stringSetValue(ctx, monitor.Tags, *f.Tags)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// setField sets a field of monitorFields the model has.
+func setField[T any](field *T, value T) {
+	if field != nil {
+		*field = value
+	}
+}
+
+// refreshField sets a field of monitorFields the model has from its prior
+// value.
+func refreshField[T any](field *T, value func(prior T) T) {
+	if field != nil {
+		*field = value(*field)
+	}
+}
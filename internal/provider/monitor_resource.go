@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+  "errors"
   "fmt"
+  "strings"
+  "time"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -10,7 +13,13 @@ import (
 	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
   "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
   "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+  "github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+  "github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+  "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+  "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+  "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
   "github.com/hashicorp/terraform-plugin-framework/path"
+  "github.com/hashicorp/terraform-plugin-framework/diag"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -18,8 +27,14 @@ var (
   _ resource.Resource                = &MonitorResource{}
   _ resource.ResourceWithConfigure   = &MonitorResource{}
   _ resource.ResourceWithImportState = &MonitorResource{}
+  _ resource.ResourceWithValidateConfig = &MonitorResource{}
+  _ resource.ResourceWithModifyPlan     = &MonitorResource{}
 )
 
+// defaultFirstRunTimeout bounds how long Create waits for the first result
+// of a monitor when first_run_timeout is not set.
+const defaultFirstRunTimeout = 10 * time.Minute
+
 // NewOrderResource is a helper function to simplify the provider implementation.
 func NewMonitorResource() resource.Resource {
   return &MonitorResource{}
@@ -38,12 +53,32 @@ type MonitorResourceModel struct {
   AbsolutePath          types.String            `tfsdk:"absolute_path"`
   EntityType            types.String            `tfsdk:"entity_type"`
   CreatedAt             types.String            `tfsdk:"created_at"`
+  UpdatedAt             types.String            `tfsdk:"updated_at"`
+  IsEnabled             types.Bool              `tfsdk:"is_enabled"`
   CustomSql             types.String            `tfsdk:"custom_sql"`
   CustomWhereClause     types.String            `tfsdk:"custom_where_clause"`
   IncrementalColumnName types.String            `tfsdk:"incremental_column_name"`
   IncrementalDays       types.Int64             `tfsdk:"incremental_days"`
   IncrementalHours      types.Int64             `tfsdk:"incremental_hours"`
   IncrementalMinutes    types.Int64             `tfsdk:"incremental_minutes"`
+  WaitForFirstRun       types.Bool              `tfsdk:"wait_for_first_run"`
+  FirstRunTimeout       types.String            `tfsdk:"first_run_timeout"`
+  FirstRunResult        types.Float64           `tfsdk:"first_run_result"`
+  FirstRunLowerBound    types.Float64           `tfsdk:"first_run_lower_bound"`
+  FirstRunUpperBound    types.Float64           `tfsdk:"first_run_upper_bound"`
+  FirstRunPassed        types.Bool              `tfsdk:"first_run_passed"`
+  ThresholdType         types.String            `tfsdk:"threshold_type"`
+  LowerBound            types.Float64           `tfsdk:"lower_bound"`
+  UpperBound            types.Float64           `tfsdk:"upper_bound"`
+  Sensitivity           types.String            `tfsdk:"sensitivity"`
+  TrainingWindowDays    types.Int64             `tfsdk:"training_window_days"`
+  LookbackWindowDays    types.Int64             `tfsdk:"lookback_window_days"`
+  Name                  types.String            `tfsdk:"name"`
+  Description           types.String            `tfsdk:"description"`
+  Owners                types.Set               `tfsdk:"owners"`
+  Priority              types.String            `tfsdk:"priority"`
+  Tags                  types.Set               `tfsdk:"tags"`
+  AdoptExisting         types.Bool              `tfsdk:"adopt_existing"`
 }
 
 // Metadata returns the resource type name.
@@ -109,10 +144,21 @@ func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed: true,
         PlanModifiers: []planmodifier.String{
           stringplanmodifier.UseStateForUnknown(),
+        },
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed: true,
+			},
+			"is_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the monitor is enabled",
+				Computed: true,
+        PlanModifiers: []planmodifier.Bool{
+          boolplanmodifier.UseStateForUnknown(),
         },
 			},
 			"custom_sql": schema.StringAttribute{
-				MarkdownDescription: "custom sql",
+				MarkdownDescription: "Query of a CUSTOM_SQL monitor. Prefer `metaplane_custom_sql_monitor`, which checks the query at plan time",
 				Required: false,
         Optional: true,
 			},
@@ -136,14 +182,123 @@ func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, re
       "incremental_minutes": schema.Int64Attribute{
         MarkdownDescription: "Incremental minutes",
         Optional: true,
+      },
+      "wait_for_first_run": schema.BoolAttribute{
+        MarkdownDescription: "Wait on create until the first run of the monitor has a result, and fail if the run errored",
+        Optional: true,
+      },
+      "first_run_timeout": schema.StringAttribute{
+        MarkdownDescription: "How long to wait for the first run, e.g. `30m`. Defaults to `10m`",
+        Optional: true,
+        Validators: []validator.String{
+          durationValidator{},
+        },
+      },
+      "first_run_result": schema.Float64Attribute{
+        MarkdownDescription: "Result of the first run, set when `wait_for_first_run` is true",
+        Computed: true,
+        PlanModifiers: []planmodifier.Float64{
+          float64planmodifier.UseStateForUnknown(),
+        },
+      },
+      "first_run_lower_bound": schema.Float64Attribute{
+        MarkdownDescription: "Lower bound of the first run, set when `wait_for_first_run` is true",
+        Computed: true,
+        PlanModifiers: []planmodifier.Float64{
+          float64planmodifier.UseStateForUnknown(),
+        },
+      },
+      "first_run_upper_bound": schema.Float64Attribute{
+        MarkdownDescription: "Upper bound of the first run, set when `wait_for_first_run` is true",
+        Computed: true,
+        PlanModifiers: []planmodifier.Float64{
+          float64planmodifier.UseStateForUnknown(),
+        },
+      },
+      "first_run_passed": schema.BoolAttribute{
+        MarkdownDescription: "Whether the first run passed, set when `wait_for_first_run` is true",
+        Computed: true,
+        PlanModifiers: []planmodifier.Bool{
+          boolplanmodifier.UseStateForUnknown(),
+        },
+      },
+      "threshold_type": schema.StringAttribute{
+        MarkdownDescription: "Threshold type: `STATIC` for manual bounds or `ML` for the anomaly model. Defaults to the Metaplane anomaly model",
+        Optional: true,
+        Validators: []validator.String{
+          stringvalidator.OneOf(api.ThresholdTypeStatic, api.ThresholdTypeML),
+        },
+      },
+      "lower_bound": schema.Float64Attribute{
+        MarkdownDescription: "Manual lower bound, requires `threshold_type = \"STATIC\"`",
+        Optional: true,
+      },
+      "upper_bound": schema.Float64Attribute{
+        MarkdownDescription: "Manual upper bound, requires `threshold_type = \"STATIC\"`",
+        Optional: true,
+      },
+      "sensitivity": schema.StringAttribute{
+        MarkdownDescription: "Sensitivity of the anomaly model: `LOW`, `MEDIUM` or `HIGH`",
+        Optional: true,
+        Validators: []validator.String{
+          stringvalidator.OneOf("LOW", "MEDIUM", "HIGH"),
+        },
+      },
+      "training_window_days": schema.Int64Attribute{
+        MarkdownDescription: "Days of history the anomaly model is trained on",
+        Optional: true,
+        Validators: []validator.Int64{
+          int64validator.AtLeast(1),
+        },
+      },
+      "lookback_window_days": schema.Int64Attribute{
+        MarkdownDescription: "Days of history the anomaly model looks back on for each run",
+        Optional: true,
+        Validators: []validator.Int64{
+          int64validator.AtLeast(1),
+        },
+      },
+      "name": schema.StringAttribute{
+        MarkdownDescription: "Human readable name, shown in alerts",
+        Optional: true,
+      },
+      "description": schema.StringAttribute{
+        MarkdownDescription: "Description of what the monitor checks, shown in alerts",
+        Optional: true,
+      },
+      "owners": schema.SetAttribute{
+        MarkdownDescription: "Emails of the owners of the monitor",
+        ElementType: types.StringType,
+        Optional: true,
+      },
+      "priority": schema.StringAttribute{
+        MarkdownDescription: "Priority of the monitor: `CRITICAL`, `HIGH`, `MEDIUM` or `LOW`",
+        Optional: true,
+        Validators: []validator.String{
+          stringvalidator.OneOf("CRITICAL", "HIGH", "MEDIUM", "LOW"),
+        },
+      },
+      "tags": schema.SetAttribute{
+        MarkdownDescription: "Names of the tags of the monitor, usable for alert routing. Do not combine with a `metaplane_tag_assignment` of the same tag",
+        ElementType: types.StringType,
+        Optional: true,
+      },
+      "adopt_existing": schema.BoolAttribute{
+        MarkdownDescription: "Whether create may adopt an existing monitor with the same path and type. The plan shows a warning when it does. When false, such a collision fails the plan. Defaults to true",
+        Optional: true,
       },
 		},
 	}
 }
 
-// Create creates the resource and sets the initial Terraform state.
-func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-  // Retrieve values from plan
+// ModifyPlan looks for an existing monitor with the same path and type when
+// the monitor is about to be created, since Create would adopt it.
+func (r *MonitorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+  // Only check planned creates
+  if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+      return
+  }
+
   var plan MonitorResourceModel
   diags := req.Plan.Get(ctx, &plan)
   resp.Diagnostics.Append(diags...)
@@ -151,15 +306,137 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
       return
   }
 
-  // Generate API request body from plan
-  customSql := plan.CustomSql.ValueString()
-  customWhereClause := plan.CustomWhereClause.ValueString()
-  incrementalColumnName := plan.IncrementalColumnName.ValueString()
-  incrementalDays := plan.IncrementalDays.ValueInt64()
-  incrementalHours := plan.IncrementalHours.ValueInt64()
-  incrementalMinutes := plan.IncrementalMinutes.ValueInt64()
-
-  Config := api.Config{
+  if plan.ConnectionId.IsUnknown() || plan.AbsolutePath.IsUnknown() || plan.Type.IsUnknown() {
+      return
+  }
+
+  monitors, err := r.client.FindMonitors(plan.ConnectionId.ValueString(), plan.AbsolutePath.ValueString(), plan.Type.ValueString())
+  if err != nil {
+      resp.Diagnostics.AddWarning(
+          "Unable to check for existing monitors",
+          "Could not list the monitors of connection "+plan.ConnectionId.ValueString()+": "+err.Error(),
+      )
+      return
+  }
+  if len(monitors) == 0 {
+      return
+  }
+
+  existing := monitors[0]
+  detail := fmt.Sprintf("A %s monitor on %s already exists in Metaplane (ID %s, enabled: %t) and is not managed by this resource. ",
+    existing.Type, existing.AbsolutePath, existing.ID, existing.IsEnabled)
+
+  if !plan.AdoptExisting.IsNull() && !plan.AdoptExisting.ValueBool() {
+      resp.Diagnostics.AddAttributeError(
+          path.Root("type"),
+          "Monitor already exists",
+          detail+"Import it with `terraform import` or set adopt_existing to true.",
+      )
+      return
+  }
+
+  resp.Diagnostics.AddAttributeWarning(
+      path.Root("type"),
+      "Monitor already exists",
+      detail+"Applying this plan adopts it: the existing monitor is enabled and updated with this configuration.",
+  )
+}
+
+// ValidateConfig checks the threshold configuration against the monitor type.
+func (r *MonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+  var config MonitorResourceModel
+  diags := req.Config.Get(ctx, &config)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
+  }
+
+  hasBounds := !config.LowerBound.IsNull() || !config.UpperBound.IsNull()
+  hasModel := !config.Sensitivity.IsNull() || !config.TrainingWindowDays.IsNull() || !config.LookbackWindowDays.IsNull()
+  if config.ThresholdType.IsNull() && !hasBounds && !hasModel {
+    return
+  }
+
+  if config.Type.IsUnknown() || config.ThresholdType.IsUnknown() {
+    return
+  }
+
+  spec, ok := lookupMonitorType(config.Type.ValueString())
+  if !ok || !spec.thresholds {
+    resp.Diagnostics.AddAttributeError(
+        path.Root("type"),
+        "Thresholds Not Supported",
+        fmt.Sprintf("Monitor type %q does not support threshold or sensitivity settings. Supported types: %s.",
+          config.Type.ValueString(), strings.Join(thresholdMonitorTypeNames(), ", ")),
+    )
+    return
+  }
+
+  switch config.ThresholdType.ValueString() {
+  case api.ThresholdTypeStatic:
+    if !hasBounds {
+      resp.Diagnostics.AddAttributeError(
+          path.Root("threshold_type"),
+          "Missing Bounds",
+          "A STATIC threshold requires lower_bound, upper_bound or both.",
+      )
+    }
+    if hasModel {
+      resp.Diagnostics.AddAttributeError(
+          path.Root("threshold_type"),
+          "Conflicting Threshold Settings",
+          "sensitivity, training_window_days and lookback_window_days only apply to ML thresholds.",
+      )
+    }
+  default:
+    if hasBounds {
+      resp.Diagnostics.AddAttributeError(
+          path.Root("threshold_type"),
+          "Conflicting Threshold Settings",
+          "lower_bound and upper_bound require threshold_type = \"STATIC\".",
+      )
+    }
+  }
+
+  for _, bound := range []struct {
+    name  string
+    value types.Float64
+  }{{"lower_bound", config.LowerBound}, {"upper_bound", config.UpperBound}} {
+    if bound.value.IsNull() || bound.value.IsUnknown() {
+      continue
+    }
+    value := bound.value.ValueFloat64()
+    if (spec.minBound != nil && value < *spec.minBound) || (spec.maxBound != nil && value > *spec.maxBound) {
+      resp.Diagnostics.AddAttributeError(
+          path.Root(bound.name),
+          "Bound Out Of Range",
+          fmt.Sprintf("%s of a %s monitor must be %s, got: %v.",
+            bound.name, strings.ToUpper(config.Type.ValueString()), spec.boundRange(), value),
+      )
+    }
+  }
+
+  if !config.LowerBound.IsNull() && !config.LowerBound.IsUnknown() &&
+    !config.UpperBound.IsNull() && !config.UpperBound.IsUnknown() &&
+    config.LowerBound.ValueFloat64() > config.UpperBound.ValueFloat64() {
+    resp.Diagnostics.AddAttributeError(
+        path.Root("lower_bound"),
+        "Invalid Bounds",
+        "lower_bound must not be greater than upper_bound.",
+    )
+  }
+}
+
+// config builds the API monitor configuration from the model.
+func (m MonitorResourceModel) config() api.Config {
+  customSql             := m.CustomSql.ValueString()
+  customWhereClause     := m.CustomWhereClause.ValueString()
+  incrementalColumnName := m.IncrementalColumnName.ValueString()
+  incrementalDays       := m.IncrementalDays.ValueInt64()
+  incrementalHours      := m.IncrementalHours.ValueInt64()
+  incrementalMinutes    := m.IncrementalMinutes.ValueInt64()
+
+  config := api.Config{
     CustomSql:         &customSql,
     CustomWhereClause: &customWhereClause,
     IncrementalClause: &api.IncrementalClause{
@@ -170,6 +447,52 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
         Minutes: &incrementalMinutes,
       },
     },
+    Sensitivity:       m.Sensitivity.ValueStringPointer(),
+  }
+
+  if !m.ThresholdType.IsNull() {
+    config.Threshold = &api.Threshold{
+      Type:       m.ThresholdType.ValueString(),
+      LowerBound: m.LowerBound.ValueFloat64Pointer(),
+      UpperBound: m.UpperBound.ValueFloat64Pointer(),
+    }
+  }
+  if !m.TrainingWindowDays.IsNull() {
+    config.TrainingWindow = &api.Duration{Days: m.TrainingWindowDays.ValueInt64Pointer()}
+  }
+  if !m.LookbackWindowDays.IsNull() {
+    config.LookbackWindow = &api.Duration{Days: m.LookbackWindowDays.ValueInt64Pointer()}
+  }
+
+  return config
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+  // Retrieve values from plan
+  var plan MonitorResourceModel
+  diags := req.Plan.Get(ctx, &plan)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
+  }
+
+  // Generate API request body from plan
+  Config := plan.config()
+
+  // Owners and tags stay nil when unset, so that an adopted monitor keeps
+  // its own
+  var owners, tags []string
+  if !plan.Owners.IsNull() {
+      owners, diags = stringSetElements(ctx, plan.Owners)
+      resp.Diagnostics.Append(diags...)
+  }
+  if !plan.Tags.IsNull() {
+      tags, diags = stringSetElements(ctx, plan.Tags)
+      resp.Diagnostics.Append(diags...)
+  }
+  if resp.Diagnostics.HasError() {
+      return
   }
 
   newMonitor := api.NewMonitor{
@@ -179,9 +502,24 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
     CronTab:      plan.CronTab.ValueString(),
     AbsolutePath: plan.AbsolutePath.ValueString(),
     Config:       Config,
+    Name:         plan.Name.ValueStringPointer(),
+    Description:  plan.Description.ValueStringPointer(),
+    Owners:       owners,
+    Priority:     plan.Priority.ValueStringPointer(),
+    Tags:         tags,
+  }
+  // Create new monitor. adopt_existing is checked again here, since a
+  // colliding monitor may have been created after the plan.
+  adoptExisting := plan.AdoptExisting.IsNull() || plan.AdoptExisting.ValueBool()
+  monitor, err := r.client.CreateMonitor(ctx, newMonitor, adoptExisting)
+  if errors.Is(err, api.ErrMonitorExists) {
+      resp.Diagnostics.AddAttributeError(
+          path.Root("type"),
+          "Monitor already exists",
+          err.Error()+". Import it with `terraform import` or set adopt_existing to true.",
+      )
+      return
   }
-  // Create new monitor
-  monitor, err := r.client.CreateMonitor(ctx, newMonitor)
   if err != nil {
       resp.Diagnostics.AddError(
           "Error creating monitor",
@@ -190,9 +528,59 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
       return
   }
 
-  // Map response body to schema and populate Computed attribute values
-  plan.MonitorId = types.StringValue(monitor.ID)
-  plan.CreatedAt = types.StringValue(monitor.CreatedAt)
+  // Re-read the monitor so that the state holds what the API persisted
+  monitor, err = r.client.GetMonitorAfterWrite(ctx, monitor)
+  if err != nil {
+      resp.Diagnostics.AddError(
+          "Error reading monitor",
+          "Could not read monitor "+newMonitor.AbsolutePath+" after create, unexpected error: "+err.Error(),
+      )
+      return
+  }
+
+  // Map response body to schema and populate Computed attribute values.
+  // Unset owners and tags an adopted monitor kept stay null, so that they
+  // show up as drift on the next plan
+  priorOwners, priorTags := plan.Owners, plan.Tags
+  diags = plan.refresh(ctx, monitor)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
+  }
+  if priorOwners.IsNull() {
+      plan.Owners = priorOwners
+  }
+  if priorTags.IsNull() {
+      plan.Tags = priorTags
+  }
+  plan.FirstRunResult     = types.Float64Null()
+  plan.FirstRunLowerBound = types.Float64Null()
+  plan.FirstRunUpperBound = types.Float64Null()
+  plan.FirstRunPassed     = types.BoolNull()
+
+  // Optionally wait for the first evaluation. The monitor already exists at
+  // this point, so the state is saved even when the run failed and Terraform
+  // marks the resource as tainted.
+  if plan.WaitForFirstRun.ValueBool() {
+    timeout := defaultFirstRunTimeout
+    if !plan.FirstRunTimeout.IsNull() {
+      timeout, _ = time.ParseDuration(plan.FirstRunTimeout.ValueString())
+    }
+
+    status, err := r.client.WaitForMonitorStatus(ctx, monitor.ID, timeout)
+    if status != nil {
+      plan.FirstRunResult     = types.Float64Value(status.Result)
+      plan.FirstRunLowerBound = types.Float64Value(status.LowerBound)
+      plan.FirstRunUpperBound = types.Float64Value(status.UpperBound)
+      plan.FirstRunPassed     = types.BoolValue(status.Passed)
+    }
+    if err != nil {
+      resp.Diagnostics.AddError(
+          "Error waiting for monitor first run",
+          "Monitor "+monitor.ID+" was created but its first run did not succeed: "+err.Error(),
+      )
+    }
+  }
 
   // Set state to fully populated data
   diags = resp.State.Set(ctx, plan)
@@ -202,6 +590,47 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
   }
 }
 
+// fields returns the attributes of the model shared with the monitor data
+// sources, see MonitorModel.
+func (m *MonitorResourceModel) fields() monitorFields {
+  return monitorFields{
+    MonitorId:             &m.MonitorId,
+    ConnectionId:          &m.ConnectionId,
+    Type:                  &m.Type,
+    EntityType:            &m.EntityType,
+    AbsolutePath:          &m.AbsolutePath,
+    CronTab:               &m.CronTab,
+    IsEnabled:             &m.IsEnabled,
+    CreatedAt:             &m.CreatedAt,
+    UpdatedAt:             &m.UpdatedAt,
+    CustomSql:             &m.CustomSql,
+    CustomWhereClause:     &m.CustomWhereClause,
+    IncrementalColumnName: &m.IncrementalColumnName,
+    IncrementalDays:       &m.IncrementalDays,
+    IncrementalHours:      &m.IncrementalHours,
+    IncrementalMinutes:    &m.IncrementalMinutes,
+    ThresholdType:         &m.ThresholdType,
+    LowerBound:            &m.LowerBound,
+    UpperBound:            &m.UpperBound,
+    Sensitivity:           &m.Sensitivity,
+    TrainingWindowDays:    &m.TrainingWindowDays,
+    LookbackWindowDays:    &m.LookbackWindowDays,
+    Name:                  &m.Name,
+    Description:           &m.Description,
+    Owners:                &m.Owners,
+    Priority:              &m.Priority,
+    Tags:                  &m.Tags,
+  }
+}
+
+// refresh maps the monitor returned by the API onto the model the same way
+// as the data sources. Optional attributes the API reports as empty stay null
+// when they are null in the model, because Create and Update send empty
+// values for unset attributes.
+func (m *MonitorResourceModel) refresh(ctx context.Context, monitor *api.Monitor) diag.Diagnostics {
+  return m.fields().refresh(ctx, monitor)
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *MonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
   var state MonitorResourceModel
@@ -222,78 +651,11 @@ func (r *MonitorResource) Read(ctx context.Context, req resource.ReadRequest, re
       return
   }
 
-  if monitor.Config != nil {
-    if monitor.Config.IncrementalClause != nil {
-
-      // incrementalColumnName could be nil
-      if monitor.Config.IncrementalClause.ColumnName != nil {
-        incrementalColumnName := monitor.Config.IncrementalClause.ColumnName
-        state.IncrementalColumnName = types.StringValue(*incrementalColumnName)
-      } else {
-        state.IncrementalColumnName = types.StringNull()
-      }
-
-      if monitor.Config.IncrementalClause.Duration != nil {
-        days := monitor.Config.IncrementalClause.Duration.Days
-        if days != nil {
-          state.IncrementalDays = types.Int64Value(*days)
-        } else {
-          state.IncrementalDays = types.Int64Null()
-        }
-
-        hours := monitor.Config.IncrementalClause.Duration.Hours
-        if hours != nil {
-          state.IncrementalHours = types.Int64Value(*hours)
-        } else {
-          state.IncrementalHours = types.Int64Null()
-        }
-
-        minutes := monitor.Config.IncrementalClause.Duration.Minutes
-        if minutes != nil {
-          state.IncrementalMinutes = types.Int64Value(*minutes)
-        } else {
-          state.IncrementalMinutes = types.Int64Null()
-        }
-
-      } else {
-        state.IncrementalDays       = types.Int64Null()
-        state.IncrementalHours      = types.Int64Null()
-        state.IncrementalMinutes    = types.Int64Null()
-      }
-
-
-    } else {
-      state.IncrementalColumnName = types.StringNull()
-      state.IncrementalDays       = types.Int64Null()
-      state.IncrementalHours      = types.Int64Null()
-      state.IncrementalMinutes    = types.Int64Null()
-    }
-    if monitor.Config.CustomSql != nil {
-      state.CustomSql             = types.StringValue(*monitor.Config.CustomSql)
-    } else {
-      state.CustomSql = types.StringNull()
-    }
-
-    if monitor.Config.CustomWhereClause != nil {
-      state.CustomWhereClause     = types.StringValue(*monitor.Config.CustomWhereClause)
-    } else {
-      state.CustomWhereClause     = types.StringNull()
-    }
-  } else {
-    state.IncrementalColumnName = types.StringNull()
-    state.IncrementalDays       = types.Int64Null()
-    state.IncrementalHours      = types.Int64Null()
-    state.IncrementalMinutes    = types.Int64Null()
-    state.CustomSql             = types.StringNull()
-    state.CustomWhereClause     = types.StringNull()
-  }
-
-  state.Type                  = types.StringValue(monitor.Type)
-  state.CronTab               = types.StringValue(monitor.CronTab)
-  state.AbsolutePath          = types.StringValue(monitor.AbsolutePath)
-  state.CreatedAt             = types.StringValue(monitor.CreatedAt)
-  state.ConnectionId          = types.StringValue(monitor.ConnectionId)
-  state.EntityType            = types.StringValue(monitor.EntityType)
+  diags = state.refresh(ctx, monitor)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
+  }
 
   // Set refreshed state
   diags = resp.State.Set(ctx, &state)
@@ -314,31 +676,29 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
   }
 
   // Generate API request body from plan
-  customSql             := plan.CustomSql.ValueString()
-  customWhereClause     := plan.CustomWhereClause.ValueString()
-  incrementalColumnName := plan.IncrementalColumnName.ValueString()
-  incrementalDays       := plan.IncrementalDays.ValueInt64()
-  incrementalHours      := plan.IncrementalHours.ValueInt64()
-  incrementalMinutes    := plan.IncrementalMinutes.ValueInt64()
-
-  Config := api.Config{
-    CustomSql:         &customSql,
-    CustomWhereClause: &customWhereClause,
-    IncrementalClause: &api.IncrementalClause{
-      ColumnName: &incrementalColumnName,
-      Duration: &api.Duration{
-        Days: &incrementalDays,
-        Hours: &incrementalHours,
-        Minutes: &incrementalMinutes,
-      },
-    },
+  Config := plan.config()
+
+  owners, diags := stringSetElements(ctx, plan.Owners)
+  resp.Diagnostics.Append(diags...)
+  tags, diags := stringSetElements(ctx, plan.Tags)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
   }
+  name        := plan.Name.ValueString()
+  description := plan.Description.ValueString()
+  priority    := plan.Priority.ValueString()
 
   updateMonitor := api.UpdateMonitor{
-      CronTab:   plan.CronTab.ValueString(),
-      MonitorId: plan.MonitorId.ValueString(),
-      IsEnabled: true,
-      Config:    Config,
+      CronTab:     plan.CronTab.ValueString(),
+      MonitorId:   plan.MonitorId.ValueString(),
+      IsEnabled:   true,
+      Config:      Config,
+      Name:        &name,
+      Description: &description,
+      Owners:      &owners,
+      Priority:    &priority,
+      Tags:        &tags,
   }
 
   // Update existing monitor
@@ -351,8 +711,22 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
       return
   }
 
+  // Re-read the monitor so that the state holds what the API persisted
+  monitor, err = r.client.GetMonitorAfterWrite(ctx, monitor)
+  if err != nil {
+      resp.Diagnostics.AddError(
+          "Error reading monitor",
+          "Could not read monitor "+updateMonitor.MonitorId+" after update, unexpected error: "+err.Error(),
+      )
+      return
+  }
+
   // Update resource state with updated items and timestamp
-  plan.CronTab      = types.StringValue(monitor.CronTab)
+  diags = plan.refresh(ctx, monitor)
+  resp.Diagnostics.Append(diags...)
+  if resp.Diagnostics.HasError() {
+      return
+  }
 
   // Set state to fully populated data
   diags = resp.State.Set(ctx, plan)
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// monitorSpec is the desired configuration of one monitor of a monitor set,
+// i.e. a group of monitors managed by a single resource.
+type monitorSpec struct {
+	EntityType   string
+	Type         string
+	AbsolutePath string
+	CronTab      string
+	Config       api.Config
+}
+
+// incrementalClause builds the incremental clause shared by the monitors of a
+// set, nil when none of the attributes is set.
+func incrementalClause(columnName types.String, days types.Int64, hours types.Int64, minutes types.Int64) *api.IncrementalClause {
+	if columnName.IsNull() && days.IsNull() && hours.IsNull() && minutes.IsNull() {
+		return nil
+	}
+
+	clause := &api.IncrementalClause{
+		ColumnName: columnName.ValueStringPointer(),
+	}
+	if !days.IsNull() || !hours.IsNull() || !minutes.IsNull() {
+		clause.Duration = &api.Duration{
+			Days:    days.ValueInt64Pointer(),
+			Hours:   hours.ValueInt64Pointer(),
+			Minutes: minutes.ValueInt64Pointer(),
+		}
+	}
+	return clause
+}
+
+// refreshIncrementalClause maps the incremental clause of a monitor of a set
+// onto the incremental attributes of the set, so that changes made outside
+// of Terraform show up in the plan. Empty values stay null when the
+// attribute is null.
+func refreshIncrementalClause(monitor *api.Monitor, columnName *types.String, days *types.Int64, hours *types.Int64, minutes *types.Int64) {
+	clause := &api.IncrementalClause{}
+	if monitor.Config != nil && monitor.Config.IncrementalClause != nil {
+		clause = monitor.Config.IncrementalClause
+	}
+	duration := clause.Duration
+	if duration == nil {
+		duration = &api.Duration{}
+	}
+
+	*columnName = optionalStringValue(clause.ColumnName, *columnName)
+	*days = optionalInt64Value(duration.Days, *days)
+	*hours = optionalInt64Value(duration.Hours, *hours)
+	*minutes = optionalInt64Value(duration.Minutes, *minutes)
+}
+
+// monitorSetIds holds the IDs of the monitors of a set, keyed like its specs.
+// Disabled holds the monitors the set disabled, or found disabled, and still
+// owns. The API reports them as duplicates when they are created again, so
+// they are enabled again instead once their key is desired.
+type monitorSetIds struct {
+	Enabled  map[string]string
+	Disabled map[string]string
+}
+
+// newMonitorSetIds reads the IDs of a set from its monitor_ids and
+// disabled_monitor_ids attributes.
+func newMonitorSetIds(ctx context.Context, enabled types.Map, disabled types.Map) (monitorSetIds, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := monitorSetIds{Enabled: map[string]string{}, Disabled: map[string]string{}}
+	if !enabled.IsNull() && !enabled.IsUnknown() {
+		diags.Append(enabled.ElementsAs(ctx, &ids.Enabled, false)...)
+	}
+	if !disabled.IsNull() && !disabled.IsUnknown() {
+		diags.Append(disabled.ElementsAs(ctx, &ids.Disabled, false)...)
+	}
+	return ids, diags
+}
+
+// values returns the monitor_ids and disabled_monitor_ids attributes of a
+// set.
+func (ids monitorSetIds) values(ctx context.Context) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	enabled, d := types.MapValueFrom(ctx, types.StringType, ids.Enabled)
+	diags.Append(d...)
+	disabled, d := types.MapValueFrom(ctx, types.StringType, ids.Disabled)
+	diags.Append(d...)
+	return enabled, disabled, diags
+}
+
+// copy returns a copy of the IDs that can be modified.
+func (ids monitorSetIds) copy() monitorSetIds {
+	result := monitorSetIds{Enabled: map[string]string{}, Disabled: map[string]string{}}
+	for key, id := range ids.Enabled {
+		result.Enabled[key] = id
+	}
+	for key, id := range ids.Disabled {
+		result.Disabled[key] = id
+	}
+	return result
+}
+
+// reconcileMonitors makes the monitors of a set match the desired specs.
+//
+// Enabled monitors whose key is no longer desired are disabled, since
+// monitors cannot be deleted, and kept as disabled. Desired keys with a
+// disabled monitor enable it again. Other desired keys without a monitor are
+// created. An existing monitor with the same path and type that the set does
+// not own is adopted when adoptExisting is true, otherwise the collision
+// fails with api.ErrMonitorExists. Enabled monitors are only updated when
+// their spec differs from the previous one.
+//
+// The returned IDs are the ones of the set after reconciliation. On error
+// they hold the progress made so far, so that the caller can still save them.
+func reconcileMonitors(ctx context.Context, client *api.Client, connectionId string, previous map[string]monitorSpec, desired map[string]monitorSpec, current monitorSetIds, adoptExisting bool) (monitorSetIds, error) {
+	ids := current.copy()
+
+	// Disable the monitors that are no longer desired
+	for _, key := range sortedKeys(current.Enabled) {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		updateMonitor := api.UpdateMonitor{
+			MonitorId: current.Enabled[key],
+			IsEnabled: false,
+		}
+		_, err := client.UpdateMonitor(ctx, updateMonitor)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return ids, fmt.Errorf("could not disable monitor %s: %w", key, err)
+		}
+		delete(ids.Enabled, key)
+		if err == nil {
+			ids.Disabled[key] = current.Enabled[key]
+		}
+	}
+
+	// Create, enable again or update the desired monitors
+	for _, key := range sortedKeys(desired) {
+		spec := desired[key]
+		config := spec.Config
+
+		// The API keeps the incremental clause of a monitor when none is
+		// sent, clear the one that was removed from the configuration
+		if prior, ok := previous[key]; ok && prior.Config.IncrementalClause != nil && config.IncrementalClause == nil {
+			config.IncrementalClause = &api.IncrementalClause{}
+		}
+
+		if id, ok := ids.Disabled[key]; ok {
+			updateMonitor := api.UpdateMonitor{
+				MonitorId: id,
+				CronTab:   spec.CronTab,
+				IsEnabled: true,
+				Config:    config,
+			}
+			_, err := client.UpdateMonitor(ctx, updateMonitor)
+			if err != nil && !errors.Is(err, api.ErrNotFound) {
+				return ids, fmt.Errorf("could not enable monitor %s: %w", key, err)
+			}
+			delete(ids.Disabled, key)
+			if err == nil {
+				ids.Enabled[key] = id
+				continue
+			}
+		}
+
+		id, ok := ids.Enabled[key]
+		if !ok {
+			newMonitor := api.NewMonitor{
+				ConnectionId: connectionId,
+				Type:         spec.Type,
+				EntityType:   spec.EntityType,
+				CronTab:      spec.CronTab,
+				AbsolutePath: spec.AbsolutePath,
+				Config:       spec.Config,
+			}
+			monitor, err := client.CreateMonitor(ctx, newMonitor, adoptExisting)
+			if err != nil {
+				return ids, fmt.Errorf("could not create monitor %s: %w", key, err)
+			}
+			ids.Enabled[key] = monitor.ID
+			continue
+		}
+
+		if prior, ok := previous[key]; ok && reflect.DeepEqual(prior, spec) {
+			continue
+		}
+
+		updateMonitor := api.UpdateMonitor{
+			MonitorId: id,
+			CronTab:   spec.CronTab,
+			IsEnabled: true,
+			Config:    config,
+		}
+		_, err := client.UpdateMonitor(ctx, updateMonitor)
+		if err != nil {
+			return ids, fmt.Errorf("could not update monitor %s: %w", key, err)
+		}
+	}
+
+	return ids, nil
+}
+
+// checkMonitorCollisions looks for existing monitors the set does not own
+// with the path and type of a desired monitor, since creating it would
+// collide with them. Collisions are errors when adoptExisting is false, as
+// the apply would fail, and warnings otherwise, as the apply adopts them.
+func checkMonitorCollisions(client *api.Client, connectionId string, desired map[string]monitorSpec, current monitorSetIds, adoptExisting bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	keys := []string{}
+	for _, key := range sortedKeys(desired) {
+		_, enabled := current.Enabled[key]
+		_, disabled := current.Disabled[key]
+		if !enabled && !disabled {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return diags
+	}
+
+	monitors, err := client.ListMonitors(connectionId)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to check for existing monitors",
+			"Could not list the monitors of connection "+connectionId+": "+err.Error(),
+		)
+		return diags
+	}
+
+	for _, key := range keys {
+		spec := desired[key]
+		for _, existing := range monitors {
+			if !strings.EqualFold(existing.Type, spec.Type) || !strings.EqualFold(existing.AbsolutePath, spec.AbsolutePath) {
+				continue
+			}
+
+			detail := fmt.Sprintf("A %s monitor on %s already exists in Metaplane (ID %s, enabled: %t) and is not managed by this resource. ",
+				existing.Type, existing.AbsolutePath, existing.ID, existing.IsEnabled)
+			if !adoptExisting {
+				diags.AddAttributeError(
+					path.Root("adopt_existing"),
+					"Monitor already exists",
+					detail+"Remove it from the resource or set adopt_existing to true.",
+				)
+			} else {
+				diags.AddAttributeWarning(
+					path.Root("adopt_existing"),
+					"Monitor already exists",
+					detail+"Applying this plan adopts it: the existing monitor is enabled and updated with this configuration, and disabled once it is removed from the resource.",
+				)
+			}
+			break
+		}
+	}
+	return diags
+}
+
+// monitorSetError returns the detail of an error reconciling the monitors of
+// a set that may adopt existing monitors.
+func monitorSetError(action string, err error) string {
+	if errors.Is(err, api.ErrMonitorExists) {
+		return "Could not " + action + ": " + err.Error() + ". Remove it from the resource or set adopt_existing to true."
+	}
+	return "Could not " + action + ", unexpected error: " + err.Error()
+}
+
+// readMonitors reads the enabled monitors of a set. Monitors that no longer
+// exist are dropped from the returned IDs. Monitors disabled outside of
+// Terraform move to the disabled ones, so that the next plan enables them
+// again.
+func readMonitors(client *api.Client, current monitorSetIds) (map[string]*api.Monitor, monitorSetIds, error) {
+	ids := current.copy()
+	monitors := map[string]*api.Monitor{}
+	for _, key := range sortedKeys(current.Enabled) {
+		monitor, err := client.GetMonitor(current.Enabled[key])
+		if errors.Is(err, api.ErrNotFound) {
+			delete(ids.Enabled, key)
+			continue
+		}
+		if err != nil {
+			return nil, ids, fmt.Errorf("could not read monitor %s: %w", key, err)
+		}
+		if monitor.IsEnabled {
+			monitors[key] = monitor
+			continue
+		}
+		delete(ids.Enabled, key)
+		ids.Disabled[key] = monitor.ID
+	}
+	return monitors, ids, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// fakeMonitorApi serves the monitor endpoints used by monitor sets from
+// memory. Like the API, it reports a monitor with the same path and type as
+// a duplicate, whether it is enabled or not.
+type fakeMonitorApi struct {
+	monitors map[string]*api.Monitor
+	created  int
+	// updates holds the bodies of the update requests
+	updates []map[string]interface{}
+}
+
+func newFakeMonitorClient(t *testing.T) (*api.Client, *fakeMonitorApi) {
+	fake := &fakeMonitorApi{monitors: map[string]*api.Monitor{}}
+	apiKey := "test"
+	client := api.NewClient(&apiKey)
+	client.HTTPClient.Logger = nil
+	client.HTTPClient.RetryMax = 0
+	client.HTTPClient.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := fake.serve(t, req)
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Header:     http.Header{},
+			Request:    req,
+		}, nil
+	})
+	return client, fake
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (f *fakeMonitorApi) serve(t *testing.T, req *http.Request) (int, []byte) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/monitors")
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+
+	switch {
+	case req.Method == http.MethodGet && strings.HasPrefix(path, "/connection/"):
+		monitors := api.Monitors{Data: []api.Monitor{}}
+		for _, monitor := range f.monitors {
+			monitors.Data = append(monitors.Data, *monitor)
+		}
+		return f.json(t, http.StatusOK, monitors)
+
+	case req.Method == http.MethodGet:
+		monitor, ok := f.monitors[strings.TrimPrefix(path, "/")]
+		if !ok {
+			return f.json(t, http.StatusNotFound, api.ErrorResponse{ErrorMessage: "Monitor not found"})
+		}
+		return f.json(t, http.StatusOK, monitor)
+
+	case req.Method == http.MethodPost && path == "":
+		newMonitor := api.NewMonitor{}
+		if err := json.Unmarshal(body, &newMonitor); err != nil {
+			t.Fatalf("invalid create body: %s", err)
+		}
+		for _, monitor := range f.monitors {
+			if strings.EqualFold(monitor.Type, newMonitor.Type) && strings.EqualFold(monitor.AbsolutePath, newMonitor.AbsolutePath) {
+				return f.json(t, http.StatusBadRequest, api.ErrorResponse{ErrorMessage: "Monitor already exists"})
+			}
+		}
+		f.created++
+		monitor := &api.Monitor{
+			ID:           fmt.Sprintf("monitor-%d", f.created),
+			Type:         newMonitor.Type,
+			EntityType:   newMonitor.EntityType,
+			AbsolutePath: newMonitor.AbsolutePath,
+			ConnectionId: newMonitor.ConnectionId,
+			CronTab:      newMonitor.CronTab,
+			IsEnabled:    true,
+			Config:       &newMonitor.Config,
+		}
+		f.monitors[monitor.ID] = monitor
+		return f.json(t, http.StatusOK, monitor)
+
+	case req.Method == http.MethodPost:
+		monitor, ok := f.monitors[strings.TrimPrefix(path, "/")]
+		if !ok {
+			return f.json(t, http.StatusNotFound, api.ErrorResponse{ErrorMessage: "Monitor not found"})
+		}
+		update := map[string]interface{}{}
+		if err := json.Unmarshal(body, &update); err != nil {
+			t.Fatalf("invalid update body: %s", err)
+		}
+		f.updates = append(f.updates, update)
+		updateMonitor := api.UpdateMonitor{}
+		if err := json.Unmarshal(body, &updateMonitor); err != nil {
+			t.Fatalf("invalid update body: %s", err)
+		}
+		monitor.IsEnabled = updateMonitor.IsEnabled
+		if updateMonitor.CronTab != "" {
+			monitor.CronTab = updateMonitor.CronTab
+		}
+		return f.json(t, http.StatusOK, monitor)
+	}
+
+	t.Fatalf("unexpected request %s %s", req.Method, req.URL)
+	return 0, nil
+}
+
+func (f *fakeMonitorApi) json(t *testing.T, status int, value interface{}) (int, []byte) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("could not encode response: %s", err)
+	}
+	return status, body
+}
+
+// tableSpecs returns row count specs keyed by absolute path.
+func tableSpecs(absolutePaths ...string) map[string]monitorSpec {
+	specs := map[string]monitorSpec{}
+	for _, absolutePath := range absolutePaths {
+		specs[absolutePath] = monitorSpec{
+			EntityType:   "TABLE",
+			Type:         "ROW_COUNT",
+			AbsolutePath: absolutePath,
+			CronTab:      "0 * * * *",
+		}
+	}
+	return specs
+}
+
+func TestReconcileMonitorsEnablesDisabledMonitors(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeMonitorClient(t)
+
+	ids, err := reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A", "DB.S.B"), monitorSetIds{}, false)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	idB := ids.Enabled["DB.S.B"]
+
+	// The table stops matching
+	ids, err = reconcileMonitors(ctx, client, "connection", tableSpecs("DB.S.A", "DB.S.B"), tableSpecs("DB.S.A"), ids, false)
+	if err != nil {
+		t.Fatalf("disable: %s", err)
+	}
+	if _, ok := ids.Enabled["DB.S.B"]; ok || ids.Disabled["DB.S.B"] != idB {
+		t.Fatalf("expected %s to be kept as disabled, got %+v", idB, ids)
+	}
+	if fake.monitors[idB].IsEnabled {
+		t.Fatalf("expected %s to be disabled", idB)
+	}
+
+	// The table matches again
+	ids, err = reconcileMonitors(ctx, client, "connection", tableSpecs("DB.S.A"), tableSpecs("DB.S.A", "DB.S.B"), ids, false)
+	if err != nil {
+		t.Fatalf("enable: %s", err)
+	}
+	if ids.Enabled["DB.S.B"] != idB || len(ids.Disabled) != 0 {
+		t.Fatalf("expected %s to be enabled again, got %+v", idB, ids)
+	}
+	if !fake.monitors[idB].IsEnabled || len(fake.monitors) != 2 {
+		t.Fatalf("expected %s to be enabled again without a new monitor, got %d monitors", idB, len(fake.monitors))
+	}
+}
+
+func TestReconcileMonitorsEnablesMonitorsDisabledOutsideOfTerraform(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeMonitorClient(t)
+
+	ids, err := reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A"), monitorSetIds{}, false)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	idA := ids.Enabled["DB.S.A"]
+	fake.monitors[idA].IsEnabled = false
+
+	monitors, ids, err := readMonitors(client, ids)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if len(monitors) != 0 || ids.Disabled["DB.S.A"] != idA {
+		t.Fatalf("expected %s to be read as disabled, got %+v", idA, ids)
+	}
+
+	ids, err = reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A"), ids, false)
+	if err != nil {
+		t.Fatalf("enable: %s", err)
+	}
+	if ids.Enabled["DB.S.A"] != idA || !fake.monitors[idA].IsEnabled {
+		t.Fatalf("expected %s to be enabled again, got %+v", idA, ids)
+	}
+}
+
+func TestReconcileMonitorsRefusesMonitorsOfOthers(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeMonitorClient(t)
+
+	// A monitor managed elsewhere
+	others, err := reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A"), monitorSetIds{}, false)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	ids, err := reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A"), monitorSetIds{}, false)
+	if !errors.Is(err, api.ErrMonitorExists) {
+		t.Fatalf("expected %v, got %v", api.ErrMonitorExists, err)
+	}
+	if len(ids.Enabled) != 0 || len(fake.monitors) != 1 {
+		t.Fatalf("expected no monitor to be taken over, got %+v", ids)
+	}
+
+	ids, err = reconcileMonitors(ctx, client, "connection", nil, tableSpecs("DB.S.A"), monitorSetIds{}, true)
+	if err != nil {
+		t.Fatalf("adopt: %s", err)
+	}
+	if ids.Enabled["DB.S.A"] != others.Enabled["DB.S.A"] {
+		t.Fatalf("expected %s to be adopted, got %+v", others.Enabled["DB.S.A"], ids)
+	}
+}
+
+func TestReconcileMonitorsClearsIncrementalClause(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeMonitorClient(t)
+
+	columnName := "UPDATED_AT"
+	incremental := tableSpecs("DB.S.A")
+	spec := incremental["DB.S.A"]
+	spec.Config.IncrementalClause = &api.IncrementalClause{ColumnName: &columnName}
+	incremental["DB.S.A"] = spec
+
+	ids, err := reconcileMonitors(ctx, client, "connection", nil, incremental, monitorSetIds{}, false)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	_, err = reconcileMonitors(ctx, client, "connection", incremental, tableSpecs("DB.S.A"), ids, false)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if len(fake.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(fake.updates))
+	}
+	config, _ := fake.updates[0]["config"].(map[string]interface{})
+	if clause, ok := config["incrementalClause"].(map[string]interface{}); !ok || len(clause) != 0 {
+		t.Fatalf("expected an empty incremental clause, got %v", config)
+	}
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &MonitorSnoozeResource{}
+	_ resource.ResourceWithConfigure   = &MonitorSnoozeResource{}
+	_ resource.ResourceWithImportState = &MonitorSnoozeResource{}
+	_ resource.ResourceWithModifyPlan  = &MonitorSnoozeResource{}
+)
+
+// NewMonitorSnoozeResource is a helper function to simplify the provider implementation.
+func NewMonitorSnoozeResource() resource.Resource {
+	return &MonitorSnoozeResource{}
+}
+
+// MonitorSnoozeResource is the resource implementation.
+type MonitorSnoozeResource struct {
+	client *api.Client
+}
+
+type MonitorSnoozeResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	MonitorId types.String `tfsdk:"monitor_id"`
+	Until     types.String `tfsdk:"until"`
+	Reason    types.String `tfsdk:"reason"`
+	Expired   types.Bool   `tfsdk:"expired"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *MonitorSnoozeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_snooze"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *MonitorSnoozeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *MonitorSnoozeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Monitor snooze resource. Mutes the alerts of a monitor until `until`, the monitor keeps running and its configuration is untouched. " +
+			"Destroying the resource unmutes the monitor. Terraform cannot plan the removal of a resource that is still in the configuration, " +
+			"so once `until` has passed the snooze is reported as `expired` and plans warn that the resource can be removed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the snooze, the monitor ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitor_id": schema.StringAttribute{
+				MarkdownDescription: "Monitor identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"until": schema.StringAttribute{
+				MarkdownDescription: "End of the snooze, RFC 3339 timestamp",
+				Required:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Why the monitor is snoozed, shown in Metaplane",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether `until` has passed",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan computes whether the snooze expired. Snoozes that already
+// expired cannot be created, and expired snoozes are reported so that they
+// get removed from the configuration.
+func (r *MonitorSnoozeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan MonitorSnoozeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Until.IsUnknown() {
+		return
+	}
+
+	until, err := time.Parse(time.RFC3339, plan.Until.ValueString())
+	if err != nil {
+		return
+	}
+	expired := !time.Now().Before(until)
+
+	if req.State.Raw.IsNull() {
+		if expired {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("until"),
+				"Expired Monitor Snooze",
+				fmt.Sprintf("until %s has already passed, a snooze must end in the future.", plan.Until.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), false)...)
+		return
+	}
+
+	var state MonitorSnoozeResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the refreshed value while until is unchanged, so that time passing
+	// between refresh and plan does not plan an update
+	if plan.Until.Equal(state.Until) {
+		expired = state.Expired.ValueBool()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), expired)...)
+
+	if expired {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("until"),
+			"Expired Monitor Snooze",
+			fmt.Sprintf("The snooze of monitor %s expired at %s, the monitor alerts again. The resource can be removed from the configuration.", plan.MonitorId.ValueString(), plan.Until.ValueString()),
+		)
+	}
+}
+
+// refresh maps the snooze returned by the API onto the model.
+func (m *MonitorSnoozeResourceModel) refresh(snooze *api.MonitorSnooze) {
+	m.Id = m.MonitorId
+	until := snooze.Until
+	m.Until = timestampValue(&until, m.Until)
+	m.Reason = types.StringValue(snooze.Reason)
+	m.Expired = types.BoolValue(m.expired())
+	m.CreatedAt = types.StringValue(snooze.CreatedAt)
+}
+
+// expired reports whether until has passed.
+func (m MonitorSnoozeResourceModel) expired() bool {
+	until, err := time.Parse(time.RFC3339, m.Until.ValueString())
+	return err == nil && !time.Now().Before(until)
+}
+
+// snooze snoozes the monitor as planned and saves the resulting state.
+func (r *MonitorSnoozeResource) snooze(plan *MonitorSnoozeResourceModel) error {
+	// An expired snooze mutes nothing, lift the previous one in case it was
+	// still running and only keep the snooze in the state
+	if plan.expired() {
+		err := r.client.UnsnoozeMonitor(plan.MonitorId.ValueString())
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return err
+		}
+		plan.Id = plan.MonitorId
+		plan.Expired = types.BoolValue(true)
+		if plan.CreatedAt.IsUnknown() {
+			plan.CreatedAt = types.StringNull()
+		}
+		return nil
+	}
+
+	snooze := api.MonitorSnooze{
+		Until:  plan.Until.ValueString(),
+		Reason: plan.Reason.ValueString(),
+	}
+	result, err := r.client.SnoozeMonitor(plan.MonitorId.ValueString(), snooze)
+	if err != nil {
+		return err
+	}
+	plan.refresh(result)
+	return nil
+}
+
+// Create snoozes the monitor and sets the initial Terraform state.
+func (r *MonitorSnoozeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan MonitorSnoozeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.snooze(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error snoozing monitor",
+			"Could not snooze monitor "+plan.MonitorId.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *MonitorSnoozeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state MonitorSnoozeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A snooze that is gone before its end was lifted outside of Terraform
+	// and is created again, an expired one is kept until it is removed from
+	// the configuration.
+	snooze, err := r.client.GetMonitorSnooze(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		if !state.Until.IsNull() && state.expired() {
+			state.Expired = types.BoolValue(true)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Monitor Snooze",
+			"Could not read the snooze of Metaplane Monitor ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.MonitorId = state.Id
+	state.refresh(snooze)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update snoozes the monitor again and sets the updated Terraform state on success.
+func (r *MonitorSnoozeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan MonitorSnoozeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.snooze(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error snoozing monitor",
+			"Could not update the snooze of monitor "+plan.MonitorId.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete unsnoozes the monitor and removes the Terraform state on success.
+func (r *MonitorSnoozeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state MonitorSnoozeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UnsnoozeMonitor(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Monitor Snooze",
+			"Could not unsnooze monitor, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the snooze of a monitor by monitor ID.
+func (r *MonitorSnoozeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
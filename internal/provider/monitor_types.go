@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// monitorTypeSpec describes what a Metaplane monitor type supports.
+type monitorTypeSpec struct {
+	// entityType is the entity the monitor runs against, TABLE or COLUMN.
+	entityType string
+	// thresholds is true when the monitor type accepts threshold and
+	// sensitivity configuration.
+	thresholds bool
+	// minBound and maxBound limit the manual bounds, nil means unbounded.
+	minBound *float64
+	maxBound *float64
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// monitorTypes lists the monitor types known to the provider.
+var monitorTypes = map[string]monitorTypeSpec{
+	"ROW_COUNT":     {entityType: "TABLE", thresholds: true, minBound: float64Ptr(0)},
+	"FRESHNESS":     {entityType: "TABLE", thresholds: true, minBound: float64Ptr(0)},
+	"SCHEMA_CHANGE": {entityType: "TABLE"},
+	"CUSTOM_SQL":    {entityType: "TABLE", thresholds: true},
+	"NULLNESS":      {entityType: "COLUMN", thresholds: true, minBound: float64Ptr(0), maxBound: float64Ptr(100)},
+	"UNIQUENESS":    {entityType: "COLUMN", thresholds: true, minBound: float64Ptr(0), maxBound: float64Ptr(100)},
+	"CARDINALITY":   {entityType: "COLUMN", thresholds: true, minBound: float64Ptr(0)},
+	"MEAN":          {entityType: "COLUMN", thresholds: true},
+	"MEDIAN":        {entityType: "COLUMN", thresholds: true},
+	"MIN":           {entityType: "COLUMN", thresholds: true},
+	"MAX":           {entityType: "COLUMN", thresholds: true},
+	"STD_DEV":       {entityType: "COLUMN", thresholds: true},
+}
+
+// lookupMonitorType returns the spec of a monitor type, case-insensitive.
+func lookupMonitorType(monitorType string) (monitorTypeSpec, bool) {
+	spec, ok := monitorTypes[strings.ToUpper(monitorType)]
+	return spec, ok
+}
+
+// boundRange describes the allowed range of manual bounds.
+func (s monitorTypeSpec) boundRange() string {
+	switch {
+	case s.minBound != nil && s.maxBound != nil:
+		return fmt.Sprintf("between %v and %v", *s.minBound, *s.maxBound)
+	case s.minBound != nil:
+		return fmt.Sprintf("at least %v", *s.minBound)
+	case s.maxBound != nil:
+		return fmt.Sprintf("at most %v", *s.maxBound)
+	}
+	return "any number"
+}
+
+// thresholdMonitorTypeNames returns the sorted names of the monitor types
+// accepting threshold and sensitivity configuration.
+func thresholdMonitorTypeNames() []string {
+	names := []string{}
+	for name, spec := range monitorTypes {
+		if spec.thresholds {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// monitorTypeNames returns the sorted names of the monitor types running
+// against the given entity type.
+func monitorTypeNames(entityType string) []string {
+	names := []string{}
+	for name, spec := range monitorTypes {
+		if spec.entityType == entityType {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
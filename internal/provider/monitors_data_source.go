@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MonitorsDataSource{}
+
+func NewMonitorsDataSource() datasource.DataSource {
+	return &MonitorsDataSource{}
+}
+
+// MonitorsDataSource defines the data source implementation.
+type MonitorsDataSource struct {
+	client *api.Client
+}
+
+type MonitorsDataSourceModel struct {
+	ConnectionId types.String   `tfsdk:"connection_id"`
+	PathPrefix   types.String   `tfsdk:"path_prefix"`
+	PathGlob     types.String   `tfsdk:"path_glob"`
+	Type         types.String   `tfsdk:"type"`
+	EntityType   types.String   `tfsdk:"entity_type"`
+	IsEnabled    types.Bool     `tfsdk:"is_enabled"`
+	Monitors     []MonitorModel `tfsdk:"monitors"`
+}
+
+func (d *MonitorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitors"
+}
+
+func (d *MonitorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Monitors data source. Lists the monitors of a connection matching every filter set, including the disabled ones, sorted by absolute path and type. " +
+			"Paths and types are compared case-insensitively.",
+		Attributes: map[string]schema.Attribute{
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+			},
+			"path_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list monitors whose absolute path starts with this prefix, e.g. `ANALYTICS.FINANCE.`",
+				Optional:            true,
+			},
+			"path_glob": schema.StringAttribute{
+				MarkdownDescription: "Only list monitors whose absolute path matches this glob, e.g. `ANALYTICS.*.ORDERS`",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list monitors of this type, e.g. `ROW_COUNT`",
+				Optional:            true,
+			},
+			"entity_type": schema.StringAttribute{
+				MarkdownDescription: "Only list monitors of this entity type: `TABLE` or `COLUMN`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("TABLE", "COLUMN"),
+				},
+			},
+			"is_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Only list enabled, or disabled, monitors",
+				Optional:            true,
+			},
+			"monitors": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching monitors",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: monitorDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *MonitorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// matches reports whether the monitor matches every filter set.
+func (m MonitorsDataSourceModel) matches(monitor api.Monitor) bool {
+	if !m.PathPrefix.IsNull() && !strings.HasPrefix(strings.ToUpper(monitor.AbsolutePath), strings.ToUpper(m.PathPrefix.ValueString())) {
+		return false
+	}
+	if !m.PathGlob.IsNull() && !matchesAnyGlob([]string{m.PathGlob.ValueString()}, monitor.AbsolutePath) {
+		return false
+	}
+	if !m.Type.IsNull() && !strings.EqualFold(m.Type.ValueString(), monitor.Type) {
+		return false
+	}
+	if !m.EntityType.IsNull() && !strings.EqualFold(m.EntityType.ValueString(), monitor.EntityType) {
+		return false
+	}
+	return m.IsEnabled.IsNull() || m.IsEnabled.ValueBool() == monitor.IsEnabled
+}
+
+func (d *MonitorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state MonitorsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitors, err := d.client.ListMonitors(state.ConnectionId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list monitors, got error: %s", err))
+		return
+	}
+	sort.SliceStable(monitors, func(i, j int) bool {
+		a, b := monitors[i], monitors[j]
+		if !strings.EqualFold(a.AbsolutePath, b.AbsolutePath) {
+			return strings.ToUpper(a.AbsolutePath) < strings.ToUpper(b.AbsolutePath)
+		}
+		if !strings.EqualFold(a.Type, b.Type) {
+			return strings.ToUpper(a.Type) < strings.ToUpper(b.Type)
+		}
+		return a.ID < b.ID
+	})
+
+	state.Monitors = []MonitorModel{}
+	for i := range monitors {
+		if !state.matches(monitors[i]) {
+			continue
+		}
+		var model MonitorModel
+		resp.Diagnostics.Append(model.refresh(ctx, &monitors[i])...)
+		state.Monitors = append(state.Monitors, model)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -117,13 +117,32 @@ func (p *metaplaneProvider) Configure(ctx context.Context, req provider.Configur
 func (p *metaplaneProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
     NewMonitorResource,
+    NewConnectionResource,
+    NewTableMonitorsResource,
+    NewColumnMonitorsResource,
+    NewCustomSqlMonitorResource,
+    NewMonitorGroupResource,
+    NewDataContractResource,
+    NewAlertDestinationResource,
+    NewAlertRuleResource,
+    NewTagResource,
+    NewTagAssignmentResource,
+    NewMaintenanceWindowResource,
+    NewConnectionExclusionResource,
+    NewConnectionSyncSettingsResource,
+    NewUserResource,
+    NewTeamResource,
+    NewDbtIntegrationResource,
+    NewMonitorSnoozeResource,
 	}
 }
 
 func (p *metaplaneProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewMonitorDataSource,
+		NewMonitorsDataSource,
 		NewConnectionDataSource,
+		NewConnectionsDataSource,
 	}
 }
 
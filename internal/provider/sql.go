@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// forbiddenSqlKeywords are the DML, DDL, procedure, session and stage
+// keywords a monitor query may not contain, since Metaplane runs it with the
+// credentials of the connection.
+var forbiddenSqlKeywords = []string{
+	"ALTER", "CALL", "COPY", "CREATE", "DELETE", "DROP", "EXEC", "EXECUTE",
+	"GRANT", "INSERT", "MERGE", "PUT", "REMOVE", "RENAME", "REVOKE", "SET",
+	"TRUNCATE", "UNDROP", "UPDATE", "UPSERT", "USE",
+}
+
+var sqlWordRegexp = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_$]*`)
+
+// sqlDollarQuoteRegexp matches the opening delimiter of a dollar-quoted
+// literal, e.g. $$ in Snowflake or $body$ in Postgres.
+var sqlDollarQuoteRegexp = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// checkCustomSql checks that a monitor query is a single, non-empty, read-only
+// statement. It is a guardrail, not a parser: keywords are searched outside
+// of comments, string literals and quoted identifiers.
+func checkCustomSql(sql string) error {
+	code, err := stripSqlLiterals(sql)
+	if err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	code = strings.TrimSpace(strings.TrimRight(code, "; \t\r\n"))
+	if code == "" {
+		return errors.New("the query is empty")
+	}
+	if strings.Contains(code, ";") {
+		return errors.New("the query must be a single statement")
+	}
+
+	for _, word := range sqlWordRegexp.FindAllString(code, -1) {
+		if contains(forbiddenSqlKeywords, strings.ToUpper(word)) {
+			return fmt.Errorf("the query must be read-only, found %s. Quote identifiers named after SQL keywords", strings.ToUpper(word))
+		}
+	}
+
+	return nil
+}
+
+// stripSqlLiterals replaces the comments, string literals, dollar-quoted
+// literals and quoted identifiers of a query with spaces. As in standard SQL,
+// a quote is escaped by doubling it, a backslash is an ordinary character.
+func stripSqlLiterals(sql string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		switch {
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				return b.String(), nil
+			}
+			i += end
+			b.WriteByte('\n')
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return "", errors.New("the query has an unterminated comment")
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		case sql[i] == '$' && (i == 0 || !isSqlWordByte(sql[i-1])) && sqlDollarQuoteRegexp.MatchString(sql[i:]):
+			delimiter := sqlDollarQuoteRegexp.FindString(sql[i:])
+			end := strings.Index(sql[i+len(delimiter):], delimiter)
+			if end < 0 {
+				return "", fmt.Errorf("the query has an unterminated %s literal", delimiter)
+			}
+			i += len(delimiter) + end + len(delimiter) - 1
+			b.WriteByte(' ')
+		case sql[i] == '\'' || sql[i] == '"' || sql[i] == '`':
+			quote := sql[i]
+			j := i + 1
+			for ; j < len(sql); j++ {
+				if sql[j] == quote {
+					// A doubled quote escapes the quote
+					if j+1 < len(sql) && sql[j+1] == quote {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(sql) {
+				return "", fmt.Errorf("the query has an unterminated %c quote", quote)
+			}
+			i = j
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(sql[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// isSqlWordByte reports whether c may be part of an unquoted identifier.
+func isSqlWordByte(c byte) bool {
+	return c == '_' || c == '$' || ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckCustomSql(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		// err is a substring of the expected error, empty when the query is valid
+		err string
+	}{
+		{name: "select", sql: "SELECT COUNT(*) FROM ANALYTICS.FINANCE.ORDERS"},
+		{name: "trailing semicolon", sql: "SELECT 1;\n"},
+		{name: "empty", sql: "  ;  ", err: "empty"},
+		{name: "comment only", sql: "-- nothing", err: "empty"},
+		{name: "two statements", sql: "SELECT 1; SELECT 2", err: "single statement"},
+
+		{name: "keyword in string", sql: "SELECT * FROM t WHERE action = 'DROP TABLE'"},
+		{name: "semicolon in string", sql: "SELECT * FROM t WHERE note = 'a;b'"},
+		{name: "doubled quote", sql: "SELECT * FROM t WHERE name = 'O''Brien; DROP'"},
+		{name: "trailing backslash", sql: `SELECT * FROM t WHERE path = 'C:\'`},
+		{name: "backslash does not escape", sql: `SELECT * FROM t WHERE path = 'C:\' OR 1 = 1; DROP TABLE t`, err: "single statement"},
+		{name: "unterminated string", sql: "SELECT 'abc", err: "unterminated '"},
+		{name: "keyword in quoted identifier", sql: `SELECT "update" FROM t`},
+		{name: "keyword in backquoted identifier", sql: "SELECT `delete` FROM t"},
+
+		{name: "keyword in line comment", sql: "SELECT 1 -- DELETE everything\nFROM t"},
+		{name: "keyword in block comment", sql: "SELECT /* INSERT */ 1 FROM t"},
+		{name: "unterminated comment", sql: "SELECT 1 /* oops", err: "unterminated comment"},
+
+		{name: "keyword in dollar literal", sql: "SELECT $$DROP TABLE t; $$ AS s"},
+		{name: "keyword in tagged dollar literal", sql: "SELECT $body$ DELETE FROM t $body$ AS s"},
+		{name: "unterminated dollar literal", sql: "SELECT $$abc", err: "unterminated $$ literal"},
+		{name: "dollar in identifier", sql: "SELECT a$b$ FROM t"},
+
+		{name: "insert", sql: "INSERT INTO t VALUES (1)", err: "found INSERT"},
+		{name: "lowercase delete", sql: "delete from t", err: "found DELETE"},
+		{name: "copy", sql: "COPY INTO t FROM @stage", err: "found COPY"},
+		{name: "call", sql: "CALL cleanup()", err: "found CALL"},
+		{name: "execute", sql: "EXECUTE IMMEDIATE 'SELECT 1'", err: "found EXECUTE"},
+		{name: "set", sql: "SET x = 1", err: "found SET"},
+		{name: "use", sql: "USE ROLE ACCOUNTADMIN", err: "found USE"},
+		{name: "undrop", sql: "UNDROP TABLE t", err: "found UNDROP"},
+		{name: "put", sql: "PUT file:///tmp/x @stage", err: "found PUT"},
+		{name: "keyword as part of identifier", sql: "SELECT updated_at, user_id FROM t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCustomSql(tt.sql)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("checkCustomSql(%q) = %v, want nil", tt.sql, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("checkCustomSql(%q) = %v, want error containing %q", tt.sql, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// tableMonitorKinds are the monitor types a table monitor set can hold.
+var tableMonitorKinds = []string{"FRESHNESS", "ROW_COUNT", "SCHEMA_CHANGE"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &TableMonitorsResource{}
+	_ resource.ResourceWithConfigure   = &TableMonitorsResource{}
+	_ resource.ResourceWithImportState = &TableMonitorsResource{}
+	_ resource.ResourceWithModifyPlan  = &TableMonitorsResource{}
+)
+
+// NewTableMonitorsResource is a helper function to simplify the provider implementation.
+func NewTableMonitorsResource() resource.Resource {
+	return &TableMonitorsResource{}
+}
+
+// TableMonitorsResource is the resource implementation.
+type TableMonitorsResource struct {
+	client *api.Client
+}
+
+type TableMonitorsResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	ConnectionId          types.String `tfsdk:"connection_id"`
+	AbsolutePath          types.String `tfsdk:"absolute_path"`
+	Kinds                 types.Set    `tfsdk:"kinds"`
+	CronTab               types.String `tfsdk:"cron_tab"`
+	IncrementalColumnName types.String `tfsdk:"incremental_column_name"`
+	IncrementalDays       types.Int64  `tfsdk:"incremental_days"`
+	IncrementalHours      types.Int64  `tfsdk:"incremental_hours"`
+	IncrementalMinutes    types.Int64  `tfsdk:"incremental_minutes"`
+	MonitorIds            types.Map    `tfsdk:"monitor_ids"`
+	DisabledMonitorIds    types.Map    `tfsdk:"disabled_monitor_ids"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// Metadata returns the resource type name.
+func (r *TableMonitorsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_monitors"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *TableMonitorsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *TableMonitorsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Standard set of table monitors, one per kind, sharing the same schedule and incremental settings. " +
+			"Removing a kind, or destroying the resource, disables the corresponding monitor.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the set, `{connection_id}:{absolute_path}`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Connection identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"absolute_path": schema.StringAttribute{
+				MarkdownDescription: "{database}.{schema}.{table}",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kinds": schema.SetAttribute{
+				MarkdownDescription: "Monitor kinds to create on the table: " + strings.Join(tableMonitorKinds, ", "),
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(tableMonitorKinds...)),
+				},
+			},
+			"cron_tab": schema.StringAttribute{
+				MarkdownDescription: "cron job schedule in * * * * * format, shared by all monitors",
+				Required:            true,
+			},
+			"incremental_column_name": schema.StringAttribute{
+				MarkdownDescription: "Incremental column name, applied to row count and freshness monitors",
+				Optional:            true,
+			},
+			"incremental_days": schema.Int64Attribute{
+				MarkdownDescription: "Incremental days",
+				Optional:            true,
+			},
+			"incremental_hours": schema.Int64Attribute{
+				MarkdownDescription: "Incremental hours",
+				Optional:            true,
+			},
+			"incremental_minutes": schema.Int64Attribute{
+				MarkdownDescription: "Incremental minutes",
+				Optional:            true,
+			},
+			"monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Monitor identifiers keyed by kind",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"disabled_monitor_ids": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the monitors the set disabled, keyed like `monitor_ids`. They are enabled again when desired again",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether the set may take over existing monitors with the same path and type that it does not manage. The plan shows a warning when it does. Adopted monitors are disabled once removed from the set. When false, such a collision fails the plan. Defaults to false",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// ModifyPlan looks for existing monitors the set does not manage with the
+// path and type of a planned monitor, since the apply would adopt them.
+func (r *TableMonitorsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan TableMonitorsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ConnectionId.IsUnknown() || plan.AbsolutePath.IsUnknown() || plan.Kinds.IsUnknown() {
+		return
+	}
+
+	current := monitorSetIds{}
+	if !req.State.Raw.IsNull() {
+		var state TableMonitorsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		current, diags = state.monitorIds(ctx)
+		resp.Diagnostics.Append(diags...)
+	}
+	// Elements may still be unknown, the apply checks again
+	desired, diags := plan.specs(ctx)
+	if resp.Diagnostics.HasError() || diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkMonitorCollisions(r.client, plan.ConnectionId.ValueString(), desired, current, plan.AdoptExisting.ValueBool())...)
+}
+
+// specs returns the desired monitors of the set keyed by kind.
+func (m TableMonitorsResourceModel) specs(ctx context.Context) (map[string]monitorSpec, diag.Diagnostics) {
+	kinds, diags := stringSetElements(ctx, m.Kinds)
+
+	specs := map[string]monitorSpec{}
+	for _, kind := range kinds {
+		spec := monitorSpec{
+			EntityType:   "TABLE",
+			Type:         kind,
+			AbsolutePath: m.AbsolutePath.ValueString(),
+			CronTab:      m.CronTab.ValueString(),
+		}
+		if kind != "SCHEMA_CHANGE" {
+			spec.Config.IncrementalClause = incrementalClause(m.IncrementalColumnName, m.IncrementalDays, m.IncrementalHours, m.IncrementalMinutes)
+		}
+		specs[kind] = spec
+	}
+	return specs, diags
+}
+
+// monitorIds returns the IDs of the monitors of the set keyed by kind.
+func (m TableMonitorsResourceModel) monitorIds(ctx context.Context) (monitorSetIds, diag.Diagnostics) {
+	return newMonitorSetIds(ctx, m.MonitorIds, m.DisabledMonitorIds)
+}
+
+// setMonitorIds stores the IDs of the monitors of the set.
+func (m *TableMonitorsResourceModel) setMonitorIds(ctx context.Context, ids monitorSetIds) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(m.ConnectionId.ValueString() + ":" + m.AbsolutePath.ValueString())
+	m.MonitorIds, m.DisabledMonitorIds, diags = ids.values(ctx)
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *TableMonitorsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan TableMonitorsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := plan.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the monitors, saving the ones created so far on error
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), nil, desired, monitorSetIds{}, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating table monitors",
+			monitorSetError("create table monitors", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *TableMonitorsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TableMonitorsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported set has no IDs yet, look the monitors up on the table
+	if state.MonitorIds.IsNull() {
+		monitors, err := r.client.ListMonitors(state.ConnectionId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Metaplane Table Monitors",
+				"Could not list monitors of connection "+state.ConnectionId.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		for _, monitor := range monitors {
+			kind := strings.ToUpper(monitor.Type)
+			if strings.EqualFold(monitor.AbsolutePath, state.AbsolutePath.ValueString()) && monitor.IsEnabled && contains(tableMonitorKinds, kind) {
+				current.Enabled[kind] = monitor.ID
+			}
+		}
+	}
+
+	monitors, ids, err := readMonitors(r.client, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Table Monitors",
+			"Could not read monitors of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	kinds := []string{}
+	incrementalRefreshed := false
+	for _, kind := range sortedKeys(monitors) {
+		monitor := monitors[kind]
+		kinds = append(kinds, kind)
+		if monitor.CronTab != state.CronTab.ValueString() {
+			state.CronTab = types.StringValue(monitor.CronTab)
+		}
+		// Schema change monitors have no incremental clause
+		if kind != "SCHEMA_CHANGE" && !incrementalRefreshed {
+			refreshIncrementalClause(monitor, &state.IncrementalColumnName, &state.IncrementalDays, &state.IncrementalHours, &state.IncrementalMinutes)
+			incrementalRefreshed = true
+		}
+	}
+
+	state.Kinds, diags = types.SetValueFrom(ctx, types.StringType, kinds)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(state.setMonitorIds(ctx, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *TableMonitorsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TableMonitorsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, diags := state.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	desired, diags := plan.specs(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := reconcileMonitors(ctx, r.client, plan.ConnectionId.ValueString(), previous, desired, current, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating table monitors",
+			monitorSetError("update table monitors", err),
+		)
+	}
+
+	resp.Diagnostics.Append(plan.setMonitorIds(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables the monitors of the set and removes the Terraform state on success.
+func (r *TableMonitorsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TableMonitorsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.monitorIds(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := reconcileMonitors(ctx, r.client, state.ConnectionId.ValueString(), nil, nil, current, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Table Monitors",
+			"Could not disable table monitors, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a set by `{connection_id}:{absolute_path}`. The enabled
+// monitors of the table with one of the supported kinds become the set.
+func (r *TableMonitorsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	connectionId, absolutePath, ok := strings.Cut(req.ID, ":")
+	if !ok || connectionId == "" || absolutePath == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: connection_id:absolute_path. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), connectionId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("absolute_path"), absolutePath)...)
+}
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &TagAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &TagAssignmentResource{}
+	_ resource.ResourceWithImportState = &TagAssignmentResource{}
+)
+
+// NewTagAssignmentResource is a helper function to simplify the provider implementation.
+func NewTagAssignmentResource() resource.Resource {
+	return &TagAssignmentResource{}
+}
+
+// TagAssignmentResource is the resource implementation.
+type TagAssignmentResource struct {
+	client *api.Client
+}
+
+type TagAssignmentResourceModel struct {
+	Id         types.String       `tfsdk:"id"`
+	TagId      types.String       `tfsdk:"tag_id"`
+	MonitorIds types.Set          `tfsdk:"monitor_ids"`
+	Tables     []TaggedTableModel `tfsdk:"tables"`
+}
+
+type TaggedTableModel struct {
+	ConnectionId types.String `tfsdk:"connection_id"`
+	AbsolutePath types.String `tfsdk:"absolute_path"`
+}
+
+// Metadata returns the resource type name.
+func (r *TagAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_assignment"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *TagAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *TagAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tag assignment resource. Authoritatively manages the monitors and catalog tables a tag is assigned to: " +
+			"assignments made outside of Terraform are reported as drift and removed on the next apply. " +
+			"Use at most one assignment resource per tag.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the assignment, the tag ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_id": schema.StringAttribute{
+				MarkdownDescription: "Tag identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"monitor_ids": schema.SetAttribute{
+				MarkdownDescription: "Monitors the tag is assigned to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"tables": schema.SetNestedAttribute{
+				MarkdownDescription: "Catalog tables the tag is assigned to",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"connection_id": schema.StringAttribute{
+							MarkdownDescription: "Connection identifier",
+							Required:            true,
+						},
+						"absolute_path": schema.StringAttribute{
+							MarkdownDescription: "Absolute path of the table, `DATABASE.SCHEMA.TABLE`",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// assignments builds the API tag assignments from the model.
+func (m TagAssignmentResourceModel) assignments(ctx context.Context) (api.TagAssignments, diag.Diagnostics) {
+	monitorIds, diags := stringSetElements(ctx, m.MonitorIds)
+
+	tables := []api.TaggedTable{}
+	for _, table := range m.Tables {
+		tables = append(tables, api.TaggedTable{
+			ConnectionId: table.ConnectionId.ValueString(),
+			AbsolutePath: table.AbsolutePath.ValueString(),
+		})
+	}
+
+	return api.TagAssignments{
+		MonitorIds: monitorIds,
+		Tables:     tables,
+	}, diags
+}
+
+// refresh maps the tag assignments returned by the API onto the model.
+// Table paths keep their configured case.
+func (m *TagAssignmentResourceModel) refresh(ctx context.Context, tagId string, assignments *api.TagAssignments) diag.Diagnostics {
+	m.Id = types.StringValue(tagId)
+	m.TagId = types.StringValue(tagId)
+
+	monitorIds, diags := stringSetValue(ctx, assignments.MonitorIds, m.MonitorIds)
+	m.MonitorIds = monitorIds
+
+	if len(assignments.Tables) == 0 {
+		if m.Tables == nil || len(m.Tables) > 0 {
+			m.Tables = nil
+		}
+		return diags
+	}
+
+	tables := []TaggedTableModel{}
+	for _, table := range assignments.Tables {
+		absolutePath := types.StringValue(table.AbsolutePath)
+		for _, prior := range m.Tables {
+			if prior.ConnectionId.ValueString() == table.ConnectionId && strings.EqualFold(prior.AbsolutePath.ValueString(), table.AbsolutePath) {
+				absolutePath = prior.AbsolutePath
+				break
+			}
+		}
+		tables = append(tables, TaggedTableModel{
+			ConnectionId: types.StringValue(table.ConnectionId),
+			AbsolutePath: absolutePath,
+		})
+	}
+	m.Tables = tables
+
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *TagAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan TagAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *TagAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TagAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed tag assignments from API
+	assignments, err := r.client.GetTagAssignments(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Tag Assignment",
+			"Could not read assignments of Metaplane Tag ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, state.Id.ValueString(), assignments)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *TagAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan TagAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// set replaces the assignments of the tag with the planned ones and saves
+// the resulting state.
+func (r *TagAssignmentResource) set(ctx context.Context, plan TagAssignmentResourceModel, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	assignments, diags := plan.assignments(ctx)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	tagId := plan.TagId.ValueString()
+	result, err := r.client.SetTagAssignments(tagId, assignments)
+	if err != nil {
+		diagnostics.AddError(
+			"Error assigning tag",
+			"Could not assign tag "+tagId+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diagnostics.Append(plan.refresh(ctx, tagId, result)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	diagnostics.Append(state.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *TagAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TagAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unassign the tag from everything
+	assignments := api.TagAssignments{
+		MonitorIds: []string{},
+		Tables:     []api.TaggedTable{},
+	}
+	_, err := r.client.SetTagAssignments(state.Id.ValueString(), assignments)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Tag Assignment",
+			"Could not unassign tag, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *TagAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
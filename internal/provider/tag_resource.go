@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klaviyo/terraform-provider-metaplane/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &TagResource{}
+	_ resource.ResourceWithConfigure   = &TagResource{}
+	_ resource.ResourceWithImportState = &TagResource{}
+)
+
+// hexColorRegexp matches a hex color such as "#1f77b4".
+var hexColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// NewTagResource is a helper function to simplify the provider implementation.
+func NewTagResource() resource.Resource {
+	return &TagResource{}
+}
+
+// TagResource is the resource implementation.
+type TagResource struct {
+	client *api.Client
+}
+
+type TagResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Color       types.String `tfsdk:"color"`
+	Description types.String `tfsdk:"description"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *TagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *TagResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *TagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tag resource. Tags group monitors and catalog tables, e.g. by domain, for dashboards and alert routing. " +
+			"Use `metaplane_tag_assignment` to assign a tag.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Tag identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Tag name, e.g. `finance`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"color": schema.StringAttribute{
+				MarkdownDescription: "Hex color of the tag, e.g. `#1f77b4`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hexColorRegexp, "value must be a hex color such as #1f77b4"),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Tag description",
+				Optional:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "datetime created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "datetime updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// tag builds the API tag from the model.
+func (m TagResourceModel) tag() api.Tag {
+	return api.Tag{
+		ID:          m.Id.ValueString(),
+		Name:        m.Name.ValueString(),
+		Color:       m.Color.ValueStringPointer(),
+		Description: m.Description.ValueStringPointer(),
+	}
+}
+
+// refresh maps the tag returned by the API onto the model.
+func (m *TagResourceModel) refresh(tag *api.Tag) {
+	m.Id = types.StringValue(tag.ID)
+	m.Name = types.StringValue(tag.Name)
+	// The API may normalize the case of the hex digits
+	color := optionalStringValue(tag.Color, m.Color)
+	if !color.IsNull() {
+		color = caseInsensitiveStringValue(color.ValueString(), m.Color)
+	}
+	m.Color = color
+	m.Description = optionalStringValue(tag.Description, m.Description)
+	m.CreatedAt = types.StringValue(tag.CreatedAt)
+	m.UpdatedAt = types.StringValue(tag.UpdatedAt)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan TagResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new tag
+	tag, err := r.client.CreateTag(plan.tag())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating tag",
+			"Could not create tag, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.refresh(tag)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *TagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed tag value from API
+	tag, err := r.client.GetTag(state.Id.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metaplane Tag",
+			"Could not read Metaplane Tag ID "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.refresh(tag)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan TagResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing tag
+	tag, err := r.client.UpdateTag(plan.tag())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating tag",
+			"Could not update tag, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.refresh(tag)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *TagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTag(state.Id.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Metaplane Tag",
+			"Could not delete tag, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *TagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
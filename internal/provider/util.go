@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringSetValue converts a list of strings returned by the API to a set. An
+// empty list is reported as null, unless the prior value already was an empty
+// set, so that both omitting the attribute and setting it to [] are free of
+// drift.
+func stringSetValue(ctx context.Context, values []string, prior types.Set) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// stringSetElements returns the elements of a set of strings. A null or
+// unknown set yields an empty, non-nil slice.
+func stringSetElements(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	values := []string{}
+	if set.IsNull() || set.IsUnknown() {
+		return values, nil
+	}
+	diags := set.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// stringValueOrNull reports an empty string returned by the API as null.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// optionalStringValue maps an optional string returned by the API. An empty
+// string stays null when the prior value is null.
+func optionalStringValue(value *string, prior types.String) types.String {
+	if value == nil || (*value == "" && prior.IsNull()) {
+		return types.StringNull()
+	}
+	return types.StringValue(*value)
+}
+
+// optionalInt64Value maps an optional number returned by the API. A zero
+// stays null when the prior value is null.
+func optionalInt64Value(value *int64, prior types.Int64) types.Int64 {
+	if value == nil || (*value == 0 && prior.IsNull()) {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*value)
+}
+
+// caseInsensitiveStringValue maps a string the API may return in a different
+// case than configured, such as monitor types and paths. The prior value is
+// kept when both only differ in case.
+func caseInsensitiveStringValue(value string, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && strings.EqualFold(prior.ValueString(), value) {
+		return prior
+	}
+	return types.StringValue(value)
+}
+
+// timestampValue maps an optional RFC 3339 timestamp returned by the API.
+// The prior value is kept when both denote the same instant, since the API
+// may normalize the time zone or precision.
+func timestampValue(value *string, prior types.String) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorTime, err := time.Parse(time.RFC3339, prior.ValueString())
+		valueTime, valueErr := time.Parse(time.RFC3339, *value)
+		if err == nil && valueErr == nil && priorTime.Equal(valueTime) {
+			return prior
+		}
+	}
+	return types.StringValue(*value)
+}
+
+// contains reports whether value is one of values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}